@@ -5,5 +5,5 @@ if [ "$#" -ne 1 ]; then
     exit 1
 fi
 
-cd ./tools/andromeda; go run main.go $1
+cd ./tools/deploy; go run . -manifest ../andromeda/deploy.yaml $1
 
@@ -0,0 +1,141 @@
+package chainclient
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/CosmWasm/wasmd/x/wasm"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"go.uber.org/zap"
+)
+
+// DefaultMaxTxBytes - default mempool max_tx_bytes of CometBFT
+const DefaultMaxTxBytes = 1024 * 1024
+
+// ErrTxTooLarge - the batched transaction exceeds the max tx size
+var ErrTxTooLarge = errors.New("transaction exceeds max tx bytes")
+
+// msgIndexAttribute - attribute the SDK adds to the events emitted by a message
+const msgIndexAttribute = "msg_index"
+
+// BroadcastBatch - sign the transaction with all the messages, broadcast it
+// and wait until it is committed. Returns ErrTxTooLarge if the signed transaction
+// does not fit in the max tx size, nothing is broadcast in that case.
+func (s *ChainService) BroadcastBatch(signerName string, msgs []sdk.Msg, gasPrice uint64) (*coretypes.ResultTx, error) {
+	s.log.Info("Broadcasting batch", zap.Int("messages", len(msgs)))
+	return s.SendMsgs(signerName, msgs, gasPrice)
+}
+
+// MsgEvents - split the events of the transaction by the index of the message
+// which emitted them, the transaction level events like fee and signature are dropped
+func MsgEvents(res *coretypes.ResultTx, msgCount int) ([][]abci.Event, error) {
+	events := make([][]abci.Event, msgCount)
+	for _, event := range res.TxResult.GetEvents() {
+		for _, attr := range event.Attributes {
+			if attr.Key != msgIndexAttribute {
+				continue
+			}
+			index, err := strconv.Atoi(attr.Value)
+			if err != nil || index < 0 || index >= msgCount {
+				return nil, fmt.Errorf("invalid %s %q of event %s", msgIndexAttribute, attr.Value, event.Type)
+			}
+			events[index] = append(events[index], event)
+			break
+		}
+	}
+	return events, nil
+}
+
+// LastCodeID - get the id of the last stored code, 0 if there is no code
+func (s *ChainService) LastCodeID() (uint64, error) {
+	var (
+		queryPath = "/cosmwasm.wasm.v1.Query/Codes"
+		req       = &wasmtypes.QueryCodesRequest{
+			Pagination: &query.PageRequest{Limit: 1, Reverse: true},
+		}
+		res = &wasmtypes.QueryCodesResponse{}
+	)
+
+	if err := s.query(queryPath, req, res); err != nil {
+		return 0, err
+	}
+	if len(res.CodeInfos) == 0 {
+		return 0, nil
+	}
+	return res.CodeInfos[0].CodeID, nil
+}
+
+// StoreAndInstantiateContract - store the wasm code and instantiate it in one transaction.
+// The code id of the instantiate message is predicted from the last stored code,
+// so the transaction fails if another code is stored before it is committed.
+// Returns ErrTxTooLarge if the code does not fit in a batched transaction.
+func (s *ChainService) StoreAndInstantiateContract(
+	signerName string,
+	fileName string,
+	msg []byte,
+	opts InstantiateOptions,
+	gasPrice uint64,
+) (*coretypes.ResultTx, error) {
+	WASMByteCode, err := s.readArtifact(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(WASMByteCode) > s.maxTxBytes {
+		return nil, fmt.Errorf("%w: wasm file %d > %d", ErrTxTooLarge, len(WASMByteCode), s.maxTxBytes)
+	}
+
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		s.log.Fatal("account not found", zap.String("signerName", signerName))
+		return nil, errors.New("account not found")
+	}
+
+	lastCodeID, err := s.LastCodeID()
+	if err != nil {
+		return nil, fmt.Errorf("error getting last code id: %w", err)
+	}
+	codeID := lastCodeID + 1
+
+	msgs := []sdk.Msg{
+		&wasm.MsgStoreCode{
+			Sender:       acc.Address,
+			WASMByteCode: WASMByteCode,
+			InstantiatePermission: &wasmtypes.AccessConfig{
+				Permission: wasmtypes.AccessTypeEverybody,
+			},
+		},
+		&wasm.MsgInstantiateContract{
+			Sender: acc.Address,
+			Admin:  opts.Admin,
+			CodeID: codeID,
+			Label:  opts.Label,
+			Msg:    msg,
+			Funds:  opts.Funds,
+		},
+	}
+
+	s.log.Info("Deploying wasm contract in one transaction", zap.String("fileName", fileName), zap.Uint64("codeID", codeID))
+	res, err := s.BroadcastBatch(signerName, msgs, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.DecodeTxResult(res)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := result.StoreCode(0)
+	if err != nil {
+		return nil, err
+	}
+	if stored.CodeID != codeID {
+		return nil, fmt.Errorf("code stored with id %d, instantiated code %d", stored.CodeID, codeID)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,36 @@
+package chainclient
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+func TestMsgEvents(t *testing.T) {
+	event := func(typ string, attrs ...string) abci.Event {
+		e := abci.Event{Type: typ}
+		for i := 0; i < len(attrs); i += 2 {
+			e.Attributes = append(e.Attributes, abci.EventAttribute{Key: attrs[i], Value: attrs[i+1]})
+		}
+		return e
+	}
+	res := &coretypes.ResultTx{TxResult: abci.ExecTxResult{Events: []abci.Event{
+		event("tx", "fee", "100stake"),
+		event("message", "action", "/cosmwasm.wasm.v1.MsgStoreCode", msgIndexAttribute, "0"),
+		event("store_code", "code_id", "7", msgIndexAttribute, "0"),
+		event("instantiate", "_contract_address", "wasm1contract", "code_id", "7", msgIndexAttribute, "1"),
+	}}}
+
+	events, err := MsgEvents(res, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events[0]) != 2 || len(events[1]) != 1 {
+		t.Fatalf("unexpected events per message %d, %d", len(events[0]), len(events[1]))
+	}
+
+	if _, err := MsgEvents(res, 1); err == nil {
+		t.Fatal("expected error for message index out of range")
+	}
+}
@@ -0,0 +1,501 @@
+package chainclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"go.uber.org/zap"
+
+	cd "github.com/consideritdone/landslide-runner/chainclient/codec"
+)
+
+var _ ChainClientInterface = &ChainClient{}
+
+// ChainClientInterface - chain client interface.
+// It is used to interact with the blockchain,
+// perform transactions, and manage accounts.
+type ChainClientInterface interface {
+	AddAccount(name, mnemonic string)
+	AddOfflineAccount(name, mnemonic string, sequence, number uint64)
+	AddKey(name string) error
+	AddAccountWithOptions(name, mnemonic string, opts AccountOptions) error
+	AddOfflineAccountWithOptions(name, mnemonic string, sequence, number uint64, opts AccountOptions) error
+	AddMultisigAccount(name string, threshold int, keyNames []string) error
+	AddOfflineMultisigAccount(name string, threshold int, keyNames []string, sequence, number uint64) error
+	SetMultisigSigners(name string, keyNames []string) error
+	GetAccount(name string) (AccountInfo, bool)
+	IncreaseSequence(name string) error
+	SetSequence(name string, seq uint64) error
+	ReserveSequence(name string) (uint64, error)
+	ReleaseSequence(name string, seq uint64)
+	GetDenom() string
+	GetChainID() string
+	GetSignedTxBytes(signerAccountName string, msg types.Msg, gasPriceOverride uint64) ([]byte, error)
+	GetSignedTxBytesWithGas(signerAccountName string, msg types.Msg, gasLimit uint64, fee sdk.Coins) ([]byte, error)
+	GetSimulateTxBytes(signerAccountName string, msg types.Msg) ([]byte, error)
+	GetSignedBatchTxBytes(signerAccountName string, msgs []types.Msg, gasPriceOverride uint64) ([]byte, error)
+	GetSignedBatchTxBytesWithGas(signerAccountName string, msgs []types.Msg, gasLimit uint64, fee sdk.Coins) ([]byte, error)
+	GetSimulateBatchTxBytes(signerAccountName string, msgs []types.Msg) ([]byte, error)
+	CalculateFee(gasUsed uint64) (uint64, sdk.Coins)
+}
+
+type (
+	// AccountInfo - account information.
+	// Number and Sequence are only valid if Loaded is set.
+	AccountInfo struct {
+		Name     string
+		Mnemonic string
+		Sequence uint64
+		Number   uint64
+		Address  string
+		Loaded   bool
+		// MultisigSigners - keyring keys signing for the multisig account
+		MultisigSigners []string
+	}
+
+	// ChainClient - chain client.
+	ChainClient struct {
+		chainID        string
+		gasLimit       uint64
+		signerAccounts map[string]AccountInfo
+		accountsMu     sync.RWMutex
+		Codec          cd.Codec
+		keyring        keyring.Keyring
+		gasAdjustment  float64
+		minGasPrice    sdk.DecCoin
+		log            Logger
+		denom          string
+	}
+)
+
+// NewChainClient - create a new chain client with the in memory keyring.
+func NewChainClient(gasLimit uint64, prefix string, chainID string, denom string, log Logger) *ChainClient {
+	kr, err := NewKeyring()
+	if err != nil {
+		log.Fatal("error creating keyring: %v", zap.Error(err))
+		return nil
+	}
+
+	return NewChainClientWithKeyring(gasLimit, prefix, chainID, denom, kr, log)
+}
+
+// NewChainClientWithKeyring - create a new chain client signing with the keys of the keyring.
+func NewChainClientWithKeyring(
+	gasLimit uint64,
+	prefix string,
+	chainID string,
+	denom string,
+	kr keyring.Keyring,
+	log Logger,
+) *ChainClient {
+	if err := SetPrefixes(prefix); err != nil {
+		log.Fatal("error setting prefixes", zap.Error(err))
+		return nil
+	}
+	return &ChainClient{
+		chainID:        chainID,
+		denom:          denom,
+		gasLimit:       gasLimit,
+		signerAccounts: make(map[string]AccountInfo),
+		Codec:          cd.NewCodec(),
+		keyring:        kr,
+		gasAdjustment:  defaultGasAdjustment,
+		minGasPrice:    sdk.NewDecCoinFromDec(denom, defaultMinGasPrice),
+		log:            log,
+	}
+}
+
+// SetGasPrices - set the gas adjustment multiplier applied to the simulated gas
+// and the minimal gas price used to calculate the fee, e.g. "0.5stake".
+func (c *ChainClient) SetGasPrices(gasAdjustment float64, minGasPrice string) error {
+	if gasAdjustment < 1 {
+		return fmt.Errorf("gas adjustment must be at least 1, got %f", gasAdjustment)
+	}
+
+	price, err := sdk.ParseDecCoin(minGasPrice)
+	if err != nil {
+		return fmt.Errorf("invalid min gas price: %s", err)
+	}
+
+	c.gasAdjustment = gasAdjustment
+	c.minGasPrice = price
+	return nil
+}
+
+// CalculateFee - calculate the gas limit and the fee for the simulated gas.
+func (c *ChainClient) CalculateFee(gasUsed uint64) (uint64, sdk.Coins) {
+	gasLimit := uint64(math.LegacyNewDec(int64(gasUsed)).
+		Mul(math.LegacyMustNewDecFromStr(strconv.FormatFloat(c.gasAdjustment, 'f', -1, 64))).
+		Ceil().
+		TruncateInt64())
+	feeAmount := c.minGasPrice.Amount.MulInt64(int64(gasLimit)).Ceil().TruncateInt()
+
+	return gasLimit, sdk.NewCoins(sdk.NewCoin(c.minGasPrice.Denom, feeAmount))
+}
+
+// AddAccount - add account to the chain client,
+// the account number and sequence are loaded from the chain before the first transaction.
+func (c *ChainClient) AddAccount(name, mnemonic string) {
+	c.addAccount(AccountInfo{Name: name, Mnemonic: mnemonic})
+}
+
+// AddOfflineAccount - add account with the known account number and sequence,
+// used to sign transactions without a connection to the chain.
+func (c *ChainClient) AddOfflineAccount(name, mnemonic string, sequence, number uint64) {
+	c.addAccount(AccountInfo{
+		Name:     name,
+		Mnemonic: mnemonic,
+		Sequence: sequence,
+		Number:   number,
+		Loaded:   true,
+	})
+}
+
+// AddKey - add the account of the key stored in the keyring,
+// the account number and sequence are loaded from the chain before the first transaction.
+func (c *ChainClient) AddKey(name string) error {
+	record, err := c.keyring.Key(name)
+	if err != nil {
+		return fmt.Errorf("key %s not found: %w", name, err)
+	}
+
+	addr, err := record.GetAddress()
+	if err != nil {
+		return fmt.Errorf("error getting address of key %s: %w", name, err)
+	}
+
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	c.signerAccounts[name] = AccountInfo{Name: name, Address: addr.String()}
+	return nil
+}
+
+// Keyring - get the keyring holding the keys of the accounts.
+func (c *ChainClient) Keyring() keyring.Keyring {
+	return c.keyring
+}
+
+// addAccount - derive the default secp256k1 account key from the mnemonic and add the account
+func (c *ChainClient) addAccount(acc AccountInfo) {
+	if err := c.addAccountWithOptions(acc, AccountOptions{}); err != nil {
+		c.log.Fatal("error creating account: %v", zap.Error(err))
+	}
+}
+
+// addAccountWithOptions - derive the account key of the options from the mnemonic and add the account
+func (c *ChainClient) addAccountWithOptions(acc AccountInfo, opts AccountOptions) error {
+	algo, hdPath, err := SigningAlgo(opts)
+	if err != nil {
+		return err
+	}
+
+	newAcc, err := c.keyring.NewAccount(acc.Name, acc.Mnemonic, "", hdPath, algo)
+	if err != nil {
+		return fmt.Errorf("error creating account %s: %w", acc.Name, err)
+	}
+
+	addr, err := newAcc.GetAddress()
+	if err != nil {
+		return fmt.Errorf("error getting address of account %s: %w", acc.Name, err)
+	}
+	acc.Address = addr.String()
+
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	c.signerAccounts[acc.Name] = acc
+	return nil
+}
+
+// loadAccount - set the account number and sequence loaded from the chain,
+// an account which is already loaded is not changed.
+func (c *ChainClient) loadAccount(name string, number, sequence uint64) error {
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	acc, ok := c.signerAccounts[name]
+	if !ok {
+		return fmt.Errorf("account not found")
+	}
+	if acc.Loaded {
+		return nil
+	}
+
+	acc.Number = number
+	acc.Sequence = sequence
+	acc.Loaded = true
+	c.signerAccounts[name] = acc
+	return nil
+}
+
+// GetChainID - get the chain id used to sign the transactions.
+func (c *ChainClient) GetChainID() string {
+	return c.chainID
+}
+
+// GetDenom - get the denomination used by the chain client.
+func (c *ChainClient) GetDenom() string {
+	return c.denom
+}
+
+// GetAccount - get account by name.
+func (c *ChainClient) GetAccount(name string) (AccountInfo, bool) {
+	c.accountsMu.RLock()
+	defer c.accountsMu.RUnlock()
+	acc, ok := c.signerAccounts[name]
+	return acc, ok
+}
+
+// IncreaseSequence - increase sequence number for the account.
+func (c *ChainClient) IncreaseSequence(name string) error {
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	acc, ok := c.signerAccounts[name]
+	if !ok {
+		return fmt.Errorf("account not found")
+	}
+
+	acc.Sequence++
+	c.signerAccounts[name] = acc
+	return nil
+}
+
+// SetSequence - sets a sequence number for the account.
+func (c *ChainClient) SetSequence(name string, seq uint64) error {
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	acc, ok := c.signerAccounts[name]
+	if !ok {
+		return fmt.Errorf("account not found")
+	}
+
+	acc.Sequence = seq
+	c.signerAccounts[name] = acc
+	return nil
+}
+
+// ReserveSequence - get the sequence for the next transaction of the account and increase it,
+// so concurrent transactions of the same account get different sequences.
+func (c *ChainClient) ReserveSequence(name string) (uint64, error) {
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	acc, ok := c.signerAccounts[name]
+	if !ok {
+		return 0, fmt.Errorf("account not found")
+	}
+
+	seq := acc.Sequence
+	acc.Sequence++
+	c.signerAccounts[name] = acc
+	return seq, nil
+}
+
+// ReleaseSequence - give back the reserved sequence of a transaction which was not accepted,
+// it is only given back if no later sequence was reserved.
+func (c *ChainClient) ReleaseSequence(name string, seq uint64) {
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	acc, ok := c.signerAccounts[name]
+	if !ok || acc.Sequence != seq+1 {
+		return
+	}
+
+	acc.Sequence = seq
+	c.signerAccounts[name] = acc
+}
+
+// GetSignedTxBytes - get signed tx bytes.
+func (c *ChainClient) GetSignedTxBytes(
+	signerAccountName string,
+	msg types.Msg,
+	gasPriceOverride uint64,
+) ([]byte, error) {
+	return c.GetSignedBatchTxBytes(signerAccountName, []types.Msg{msg}, gasPriceOverride)
+}
+
+// GetSignedBatchTxBytes - get signed bytes of the tx with all the messages.
+// The gas can not be simulated offline, AutoGas is an error.
+func (c *ChainClient) GetSignedBatchTxBytes(
+	signerAccountName string,
+	msgs []types.Msg,
+	gasPriceOverride uint64,
+) ([]byte, error) {
+	gasLimit, fee, err := c.fixedFee(gasPriceOverride)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetSignedBatchTxBytesWithGas(signerAccountName, msgs, gasLimit, fee)
+}
+
+// fixedFee - gas limit and fee of a transaction with the gas price override,
+// the gas limit is twice the fee amount
+func (c *ChainClient) fixedFee(gasPriceOverride uint64) (uint64, sdk.Coins, error) {
+	switch {
+	case gasPriceOverride == AutoGas:
+		return 0, nil, errors.New("AutoGas requires the simulation, sign the transaction with ChainService")
+	case gasPriceOverride > maxGasPrice:
+		return 0, nil, fmt.Errorf("gas price %d exceeds %d", gasPriceOverride, maxGasPrice)
+	}
+
+	// Set the gas price
+	var gasPrice sdk.Coins
+	if gasPriceOverride == 0 {
+		gasPrice = sdk.NewCoins(sdk.NewInt64Coin(c.denom, 100000))
+	} else {
+		gasPrice = sdk.NewCoins(sdk.NewInt64Coin(c.denom, int64(gasPriceOverride)))
+	}
+
+	return gasPrice.AmountOf(c.denom).Mul(math.NewInt(2)).Uint64(), gasPrice, nil
+}
+
+// GetSignedTxBytesWithGas - get signed tx bytes with the given gas limit and fee.
+func (c *ChainClient) GetSignedTxBytesWithGas(
+	signerAccountName string,
+	msg types.Msg,
+	gasLimit uint64,
+	fee sdk.Coins,
+) ([]byte, error) {
+	return c.GetSignedBatchTxBytesWithGas(signerAccountName, []types.Msg{msg}, gasLimit, fee)
+}
+
+// GetSignedBatchTxBytesWithGas - get signed bytes of the tx with all the messages
+// with the given gas limit and fee.
+func (c *ChainClient) GetSignedBatchTxBytesWithGas(
+	signerAccountName string,
+	msgs []types.Msg,
+	gasLimit uint64,
+	fee sdk.Coins,
+) ([]byte, error) {
+	acc, exists := c.GetAccount(signerAccountName)
+	if !exists {
+		return nil, fmt.Errorf("account not found")
+	}
+
+	return c.signBatchTx(acc, msgs, gasLimit, fee, acc.Sequence)
+}
+
+// signBatchTx - sign the tx with all the messages with the given sequence
+func (c *ChainClient) signBatchTx(
+	acc AccountInfo,
+	msgs []types.Msg,
+	gasLimit uint64,
+	fee sdk.Coins,
+	sequence uint64,
+) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no messages")
+	}
+	if !acc.Loaded {
+		return nil, fmt.Errorf("account %s is not loaded from the chain", acc.Name)
+	}
+
+	signer, err := c.keyring.Key(acc.Name)
+	if err != nil {
+		return nil, fmt.Errorf("not found signer: %s", err)
+	}
+
+	// Create a new tx builder and set the messages
+	txBuilder := c.Codec.GetTxConfig().NewTxBuilder()
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return nil, fmt.Errorf("set msg error: %s", err)
+	}
+	// Set the fee amount and gas limit
+	txBuilder.SetFeeAmount(fee)
+	txBuilder.SetGasLimit(gasLimit)
+
+	// Sign the transaction, the multisig account is signed by its signer keys
+	if len(acc.MultisigSigners) > 0 {
+		if err := c.signMultisig(txBuilder, acc, sequence); err != nil {
+			return nil, err
+		}
+	} else {
+		factory := tx.Factory{}.
+			WithKeybase(c.keyring).
+			WithChainID(c.chainID).
+			WithAccountNumber(acc.Number).
+			WithSequence(sequence).
+			WithTxConfig(c.Codec.GetTxConfig())
+
+		if err := tx.Sign(context.Background(), factory, signer.Name, txBuilder, true); err != nil {
+			return nil, fmt.Errorf("sign tx error: %s", err)
+		}
+	}
+
+	txBytes, err := c.Codec.GetTxConfig().TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("tx encode error: %s", err)
+	}
+
+	return txBytes, nil
+}
+
+// GetSimulateTxBytes - get tx bytes for the simulation,
+// the tx has the signer public key and an empty signature.
+func (c *ChainClient) GetSimulateTxBytes(signerAccountName string, msg types.Msg) ([]byte, error) {
+	return c.GetSimulateBatchTxBytes(signerAccountName, []types.Msg{msg})
+}
+
+// GetSimulateBatchTxBytes - get bytes of the tx with all the messages for the simulation.
+func (c *ChainClient) GetSimulateBatchTxBytes(signerAccountName string, msgs []types.Msg) ([]byte, error) {
+	acc, exists := c.GetAccount(signerAccountName)
+	if !exists {
+		return nil, fmt.Errorf("account not found")
+	}
+
+	return c.simulateBatchTxBytes(acc, msgs, acc.Sequence)
+}
+
+// simulateBatchTxBytes - get bytes of the tx with all the messages for the simulation with the given sequence
+func (c *ChainClient) simulateBatchTxBytes(acc AccountInfo, msgs []types.Msg, sequence uint64) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no messages")
+	}
+	if !acc.Loaded {
+		return nil, fmt.Errorf("account %s is not loaded from the chain", acc.Name)
+	}
+
+	signer, err := c.keyring.Key(acc.Name)
+	if err != nil {
+		return nil, fmt.Errorf("not found signer: %s", err)
+	}
+
+	pubKey, err := signer.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("get pubkey error: %s", err)
+	}
+
+	txBuilder := c.Codec.GetTxConfig().NewTxBuilder()
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return nil, fmt.Errorf("set msg error: %s", err)
+	}
+
+	sig := signing.SignatureV2{
+		PubKey: pubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode: signing.SignMode_SIGN_MODE_DIRECT,
+		},
+		Sequence: sequence,
+	}
+	if len(acc.MultisigSigners) > 0 {
+		if sig, err = c.multisigSimulateSignature(acc, sequence); err != nil {
+			return nil, err
+		}
+	}
+	if err := txBuilder.SetSignatures(sig); err != nil {
+		return nil, fmt.Errorf("set signatures error: %s", err)
+	}
+
+	txBytes, err := c.Codec.GetTxConfig().TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("tx encode error: %s", err)
+	}
+
+	return txBytes, nil
+}
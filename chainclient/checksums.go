@@ -0,0 +1,102 @@
+package chainclient
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumsFile - sha256 checksums of the artifacts written by the cosmwasm optimizer.
+// checksums_intermediate.txt of the optimizer has the checksums of the unoptimized builds
+// and is not used.
+const ChecksumsFile = "checksums.txt"
+
+// ErrNoChecksums - the artifacts directory has no checksums file
+var ErrNoChecksums = errors.New("no " + ChecksumsFile)
+
+// optimizerArchSuffixes - suffixes added to the artifact names by the arm64 and x86 optimizer images
+var optimizerArchSuffixes = []string{"-aarch64", "-x86_64"}
+
+// ArtifactChecksum - hex encoded sha256 of the wasm file,
+// the same as the code data hash stored on chain
+func ArtifactChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// LoadChecksums - load checksums.txt of the artifacts directory,
+// returns the checksums by artifact file name
+func LoadChecksums(dir string) (map[string]string, error) {
+	f, err := os.Open(filepath.Join(dir, ChecksumsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoChecksums
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid %s line %q", ChecksumsFile, scanner.Text())
+		}
+		checksums[artifactName(fields[1])] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", ChecksumsFile, err)
+	}
+	return checksums, nil
+}
+
+// VerifyArtifact - check the wasm code against checksums.txt next to the artifact,
+// returns the checksum of the code or ErrNoChecksums if there is nothing to verify against
+func VerifyArtifact(path string, code []byte) (string, error) {
+	sum := sha256.Sum256(code)
+	checksum := hex.EncodeToString(sum[:])
+
+	checksums, err := LoadChecksums(filepath.Dir(path))
+	if err != nil {
+		return checksum, err
+	}
+
+	expected, ok := checksums[artifactName(path)]
+	if !ok {
+		return checksum, fmt.Errorf("%s is not in %s", filepath.Base(path), ChecksumsFile)
+	}
+	if expected != checksum {
+		return checksum, fmt.Errorf("checksum mismatch for %s: %s, expected %s", filepath.Base(path), checksum, expected)
+	}
+	return checksum, nil
+}
+
+// artifactName - file name of the artifact without the optimizer architecture suffix
+func artifactName(path string) string {
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for _, suffix := range optimizerArchSuffixes {
+		base = strings.TrimSuffix(base, suffix)
+	}
+	return base + ext
+}
@@ -0,0 +1,44 @@
+package chainclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyArtifact(t *testing.T) {
+	dir := t.TempDir()
+	code := []byte("\x00asm")
+	checksums := "" +
+		"9842a4acc99a35abaf578313bca2282d1a80256420555a140ed91375dbcabd8f  other.wasm\n" +
+		"cd5d4935a48c0672cb06407bb443bc0087aff947c6b864bac886982c73b3027f  token-aarch64.wasm\n" +
+		"0000000000000000000000000000000000000000000000000000000000000000  stale.wasm\n"
+	for name, content := range map[string][]byte{
+		ChecksumsFile: []byte(checksums),
+		"token.wasm":  code,
+		"stale.wasm":  code,
+		"extra.wasm":  code,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checksum, err := VerifyArtifact(filepath.Join(dir, "token.wasm"), code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := ArtifactChecksum(filepath.Join(dir, "token.wasm")); checksum != want {
+		t.Fatalf("checksum %s, expected %s", checksum, want)
+	}
+
+	for _, name := range []string{"stale.wasm", "extra.wasm"} {
+		if _, err := VerifyArtifact(filepath.Join(dir, name), code); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if _, err := VerifyArtifact(filepath.Join(t.TempDir(), "token.wasm"), code); err != ErrNoChecksums {
+		t.Errorf("expected ErrNoChecksums, got %v", err)
+	}
+}
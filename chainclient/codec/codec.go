@@ -0,0 +1,84 @@
+package codec
+
+import (
+	"cosmossdk.io/x/feegrant"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdkcodec "github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	"github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	icacontrollertypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	ibctypes "github.com/cosmos/ibc-go/v8/modules/core/types"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+
+	"github.com/consideritdone/landslide-runner/chainclient/crypto/ethsecp256k1"
+)
+
+var _ Codec = codec{}
+
+type (
+	codec struct {
+		enc encodingConfig
+	}
+
+	encodingConfig struct {
+		InterfaceRegistry cdctypes.InterfaceRegistry
+		Marshaler         sdkcodec.Codec
+		TxConfig          client.TxConfig
+	}
+)
+
+func makeEncodingConfig() encodingConfig {
+	interfaceRegistry := cdctypes.NewInterfaceRegistry()
+	// messages of the modules the tools send, needed to encode and decode the transactions as JSON
+	std.RegisterInterfaces(interfaceRegistry)
+	authtypes.RegisterInterfaces(interfaceRegistry)
+	banktypes.RegisterInterfaces(interfaceRegistry)
+	wasmtypes.RegisterInterfaces(interfaceRegistry)
+	authz.RegisterInterfaces(interfaceRegistry)
+	feegrant.RegisterInterfaces(interfaceRegistry)
+	govv1.RegisterInterfaces(interfaceRegistry)
+	stakingtypes.RegisterInterfaces(interfaceRegistry)
+	distrtypes.RegisterInterfaces(interfaceRegistry)
+	// messages of the in-process relayer and the IBC tests
+	ibctypes.RegisterInterfaces(interfaceRegistry)
+	ibctm.RegisterInterfaces(interfaceRegistry)
+	transfertypes.RegisterInterfaces(interfaceRegistry)
+	icacontrollertypes.RegisterInterfaces(interfaceRegistry)
+	// keys of the Ethermint-style chains
+	ethsecp256k1.RegisterInterfaces(interfaceRegistry)
+
+	marshaller := sdkcodec.NewProtoCodec(interfaceRegistry)
+	txCfg := tx.NewTxConfig(marshaller, tx.DefaultSignModes)
+
+	return encodingConfig{
+		InterfaceRegistry: interfaceRegistry,
+		Marshaler:         marshaller,
+		TxConfig:          txCfg,
+	}
+}
+
+func NewCodec() Codec {
+	enc := makeEncodingConfig()
+	return codec{enc: enc}
+}
+
+func (c codec) GetTxConfig() client.TxConfig {
+	return c.enc.TxConfig
+}
+
+func (c codec) GetMarshaler() sdkcodec.Codec {
+	return c.enc.Marshaler
+}
+
+func (c codec) GetInterfaceRegistry() cdctypes.InterfaceRegistry {
+	return c.enc.InterfaceRegistry
+}
@@ -0,0 +1,15 @@
+package codec
+
+import (
+	"github.com/cosmos/cosmos-sdk/client"
+	sdkcodec "github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+)
+
+type (
+	Codec interface {
+		GetTxConfig() client.TxConfig
+		GetMarshaler() sdkcodec.Codec
+		GetInterfaceRegistry() cdctypes.InterfaceRegistry
+	}
+)
@@ -0,0 +1,221 @@
+// Package ethsecp256k1 implements the eth_secp256k1 keys of the Ethermint-style chains:
+// secp256k1 keys with Ethereum addresses, the keccak256 hash of the uncompressed public key,
+// and recoverable signatures of the keccak256 hash of the sign bytes.
+// The keys are encoded with the type URLs of Ethermint, so the transactions they sign
+// are accepted by the apps which register the Ethermint crypto types.
+package ethsecp256k1
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+
+	cmtcrypto "github.com/cometbft/cometbft/crypto"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// PrivKeySize - size of the private key in bytes
+	PrivKeySize = 32
+	// PubKeySize - size of the compressed public key in bytes
+	PubKeySize = 33
+	// SignatureSize - size of the [R || S || V] signature in bytes
+	SignatureSize = 65
+
+	// KeyType - type of the keys
+	KeyType = "eth_secp256k1"
+
+	// PrivKeyName and PubKeyName - amino routes of the keys
+	PrivKeyName = "ethermint/PrivKeyEthSecp256k1"
+	PubKeyName  = "ethermint/PubKeyEthSecp256k1"
+)
+
+var (
+	_ cryptotypes.PrivKey = &PrivKey{}
+	_ cryptotypes.PubKey  = &PubKey{}
+)
+
+func init() {
+	// amino encodes the keys in the armored key exports and the multisig addresses
+	RegisterLegacyAminoCodec(legacy.Cdc)
+	multisig.AminoCdc.RegisterConcrete(&PubKey{}, PubKeyName, nil)
+}
+
+// RegisterLegacyAminoCodec - register the keys with the amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(&PubKey{}, PubKeyName, nil)
+	cdc.RegisterConcrete(&PrivKey{}, PrivKeyName, nil)
+}
+
+// RegisterInterfaces - register the keys as the implementations of the crypto interfaces
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*cryptotypes.PubKey)(nil), &PubKey{})
+	registry.RegisterImplementations((*cryptotypes.PrivKey)(nil), &PrivKey{})
+}
+
+// Keccak256 - the legacy keccak256 hash used by Ethereum
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// PrivKey - eth_secp256k1 private key
+type PrivKey struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+// GenerateKey - create the private key from the 32 bytes derived from the mnemonic
+func GenerateKey(bz []byte) *PrivKey {
+	key := make([]byte, PrivKeySize)
+	copy(key, bz)
+	return &PrivKey{Key: key}
+}
+
+// Bytes - the raw private key
+func (privKey *PrivKey) Bytes() []byte {
+	if privKey == nil {
+		return nil
+	}
+	return privKey.Key
+}
+
+// PubKey - the compressed public key
+func (privKey *PrivKey) PubKey() cryptotypes.PubKey {
+	return &PubKey{Key: secp256k1.PrivKeyFromBytes(privKey.Key).PubKey().SerializeCompressed()}
+}
+
+// Equals - compare the keys in constant time
+func (privKey *PrivKey) Equals(other cryptotypes.LedgerPrivKey) bool {
+	return privKey.Type() == other.Type() && subtle.ConstantTimeCompare(privKey.Bytes(), other.Bytes()) == 1
+}
+
+// Type - the key type
+func (privKey *PrivKey) Type() string {
+	return KeyType
+}
+
+// Sign - sign the keccak256 hash of the message, the signature is [R || S || V] with V = 0 or 1
+func (privKey *PrivKey) Sign(msg []byte) ([]byte, error) {
+	if len(privKey.Key) != PrivKeySize {
+		return nil, fmt.Errorf("invalid private key size %d", len(privKey.Key))
+	}
+
+	// the compact signature is [27 + V || R || S]
+	compact := ecdsa.SignCompact(secp256k1.PrivKeyFromBytes(privKey.Key), Keccak256(msg), false)
+
+	sig := make([]byte, 0, SignatureSize)
+	sig = append(sig, compact[1:]...)
+	return append(sig, compact[0]-27), nil
+}
+
+// MarshalAmino - amino encodes the raw key bytes
+func (privKey PrivKey) MarshalAmino() ([]byte, error) {
+	return privKey.Key, nil
+}
+
+// UnmarshalAmino - amino decodes the raw key bytes
+func (privKey *PrivKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PrivKeySize {
+		return fmt.Errorf("invalid private key size %d", len(bz))
+	}
+	privKey.Key = bz
+	return nil
+}
+
+// MarshalAminoJSON - amino JSON encodes the raw key bytes
+func (privKey PrivKey) MarshalAminoJSON() ([]byte, error) {
+	return privKey.MarshalAmino()
+}
+
+// UnmarshalAminoJSON - amino JSON decodes the raw key bytes
+func (privKey *PrivKey) UnmarshalAminoJSON(bz []byte) error {
+	return privKey.UnmarshalAmino(bz)
+}
+
+// PubKey - compressed eth_secp256k1 public key
+type PubKey struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+// Address - the Ethereum address, the last 20 bytes of the keccak256 hash of the uncompressed key
+func (pubKey *PubKey) Address() cryptotypes.Address {
+	pub, err := secp256k1.ParsePubKey(pubKey.Key)
+	if err != nil {
+		panic(fmt.Sprintf("invalid eth_secp256k1 public key: %v", err))
+	}
+	return cmtcrypto.Address(Keccak256(pub.SerializeUncompressed()[1:])[12:])
+}
+
+// Bytes - the compressed public key
+func (pubKey *PubKey) Bytes() []byte {
+	if pubKey == nil {
+		return nil
+	}
+	return pubKey.Key
+}
+
+// VerifySignature - verify the [R || S] or [R || S || V] signature of the keccak256 hash
+// of the message, the signatures with the high S value are rejected
+func (pubKey *PubKey) VerifySignature(msg, sig []byte) bool {
+	if len(sig) == SignatureSize {
+		sig = sig[:SignatureSize-1]
+	}
+	if len(sig) != SignatureSize-1 {
+		return false
+	}
+
+	pub, err := secp256k1.ParsePubKey(pubKey.Key)
+	if err != nil {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) || s.IsOverHalfOrder() {
+		return false
+	}
+	return ecdsa.NewSignature(&r, &s).Verify(Keccak256(msg), pub)
+}
+
+// Equals - compare the keys
+func (pubKey *PubKey) Equals(other cryptotypes.PubKey) bool {
+	return pubKey.Type() == other.Type() && bytes.Equal(pubKey.Bytes(), other.Bytes())
+}
+
+// Type - the key type
+func (pubKey *PubKey) Type() string {
+	return KeyType
+}
+
+// MarshalAmino - amino encodes the raw key bytes
+func (pubKey PubKey) MarshalAmino() ([]byte, error) {
+	return pubKey.Key, nil
+}
+
+// UnmarshalAmino - amino decodes the raw key bytes
+func (pubKey *PubKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PubKeySize {
+		return fmt.Errorf("invalid public key size %d", len(bz))
+	}
+	pubKey.Key = bz
+	return nil
+}
+
+// MarshalAminoJSON - amino JSON encodes the raw key bytes
+func (pubKey PubKey) MarshalAminoJSON() ([]byte, error) {
+	return pubKey.MarshalAmino()
+}
+
+// UnmarshalAminoJSON - amino JSON decodes the raw key bytes
+func (pubKey *PubKey) UnmarshalAminoJSON(bz []byte) error {
+	return pubKey.UnmarshalAmino(bz)
+}
@@ -0,0 +1,89 @@
+package ethsecp256k1
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The keys are the protobuf messages of ethermint/crypto/v1/ethsecp256k1/keys.proto:
+//
+//	message PubKey  { bytes key = 1; }
+//	message PrivKey { bytes key = 1; }
+//
+// The encoding is written by hand to avoid a dependency on Ethermint.
+
+const (
+	privKeyProtoName = "ethermint.crypto.v1.ethsecp256k1.PrivKey"
+	pubKeyProtoName  = "ethermint.crypto.v1.ethsecp256k1.PubKey"
+)
+
+func (privKey *PrivKey) Reset()                   { *privKey = PrivKey{} }
+func (privKey *PrivKey) String() string           { return fmt.Sprintf("PrivKey{%s}", KeyType) }
+func (*PrivKey) ProtoMessage()                    {}
+func (*PrivKey) XXX_MessageName() string          { return privKeyProtoName }
+func (privKey *PrivKey) Size() int                { return sizeKey(privKey.Key) }
+func (privKey *PrivKey) Marshal() ([]byte, error) { return marshalKey(privKey.Key), nil }
+
+func (privKey *PrivKey) Unmarshal(bz []byte) (err error) {
+	privKey.Key, err = unmarshalKey(bz)
+	return err
+}
+
+func (pubKey *PubKey) Reset()                   { *pubKey = PubKey{} }
+func (pubKey *PubKey) String() string           { return fmt.Sprintf("PubKeyEthSecp256k1{%X}", pubKey.Key) }
+func (*PubKey) ProtoMessage()                   {}
+func (*PubKey) XXX_MessageName() string         { return pubKeyProtoName }
+func (pubKey *PubKey) Size() int                { return sizeKey(pubKey.Key) }
+func (pubKey *PubKey) Marshal() ([]byte, error) { return marshalKey(pubKey.Key), nil }
+
+func (pubKey *PubKey) Unmarshal(bz []byte) (err error) {
+	pubKey.Key, err = unmarshalKey(bz)
+	return err
+}
+
+// sizeKey returns the size of the encoded key field
+func sizeKey(key []byte) int {
+	if len(key) == 0 {
+		return 0
+	}
+	return protowire.SizeTag(1) + protowire.SizeBytes(len(key))
+}
+
+// marshalKey encodes the key as the field 1, an empty key is omitted
+func marshalKey(key []byte) []byte {
+	if len(key) == 0 {
+		return []byte{}
+	}
+	bz := protowire.AppendTag(make([]byte, 0, sizeKey(key)), 1, protowire.BytesType)
+	return protowire.AppendBytes(bz, key)
+}
+
+// unmarshalKey decodes the field 1 and skips the unknown fields
+func unmarshalKey(bz []byte) ([]byte, error) {
+	var key []byte
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+
+		if num == 1 && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(bz)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			key = append([]byte{}, v...)
+			bz = bz[n:]
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, bz)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+	}
+	return key, nil
+}
@@ -0,0 +1,409 @@
+package chainclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+// ContractDeployer - uploads and instantiates contracts
+// signed by a single account.
+type ContractDeployer struct {
+	chainService *ChainService
+	client       *ChainClient
+	log          Logger
+	signer       AccountInfo
+}
+
+// NewContractDeployer creates a new instance of ContractDeployer
+func NewContractDeployer(signer AccountInfo, chainService *ChainService, client *ChainClient, log Logger) *ContractDeployer {
+	return &ContractDeployer{
+		chainService: chainService,
+		client:       client,
+		signer:       signer,
+		log:          log,
+	}
+}
+
+// SetAccount sets the account information for the deployer
+func (cd *ContractDeployer) SetAccount(signer AccountInfo) {
+	cd.signer = signer
+}
+
+// Upload uploads a smart contract and returns the code id
+func (cd *ContractDeployer) Upload(filepath string, gasPrice uint64) (uint64, error) {
+	codeID, _, err := cd.upload(filepath, gasPrice)
+	return codeID, err
+}
+
+// upload uploads a smart contract and returns the code id and the store code transaction
+func (cd *ContractDeployer) upload(filepath string, gasPrice uint64) (uint64, *coretypes.ResultTx, error) {
+	checksum, err := ArtifactChecksum(filepath)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading wasm file: %w", err)
+	}
+
+	txRes, err := cd.chainService.DeployContract(cd.signer.Name, filepath, gasPrice)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	stored, err := cd.storeCodeResult(txRes, 0)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error getting code id: %w", err)
+	}
+
+	if err := cd.verifyCode(stored.CodeID, checksum); err != nil {
+		return 0, nil, fmt.Errorf("stored code of %s does not match the artifact: %w", filepath, err)
+	}
+
+	return stored.CodeID, txRes, nil
+}
+
+// UploadAndInstantiate uploads and instantiates a smart contract
+// with the label "testing", no admin and 10000 tokens of the denom
+func (cd *ContractDeployer) UploadAndInstantiate(msg interface{}, filepath string, gasPrice uint64) (uint64, string, error) {
+	return cd.UploadAndInstantiateWithOptions(msg, filepath, InstantiateOptions{
+		Label: "testing",
+		Funds: sdk.NewCoins(sdk.NewInt64Coin(cd.client.GetDenom(), 10000)),
+	}, gasPrice)
+}
+
+// UploadAndInstantiateWithOptions uploads and instantiates a smart contract with the given label, admin and funds,
+// set the admin to migrate the contract later
+func (cd *ContractDeployer) UploadAndInstantiateWithOptions(
+	msg interface{},
+	filepath string,
+	opts InstantiateOptions,
+	gasPrice uint64,
+) (uint64, string, error) {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		cd.log.Fatal("error marshaling instantiate message", zap.Error(err))
+		return 0, "", err
+	}
+
+	codeID, _, err := cd.upload(filepath, gasPrice)
+	if err != nil {
+		cd.log.Fatal("error uploading wasm contract", zap.Error(err))
+		return 0, "", err
+	}
+
+	txRes, err := cd.chainService.InstantiateContractWithOptions(cd.signer.Name, codeID, msgBytes, opts, gasPrice)
+	if err != nil {
+		cd.log.Fatal("error instantiating wasm contract", zap.Error(err))
+		return 0, "", err
+	}
+
+	instantiated, err := cd.instantiateResult(txRes, 0)
+	if err != nil {
+		cd.log.Fatal("error extracting contract details", zap.Error(err))
+		return 0, "", err
+	}
+
+	cd.log.Info(
+		fmt.Sprintf("Contract deployed: %s", filepath),
+		zap.String("contract_address", instantiated.Address),
+		zap.Uint64("code_id", instantiated.CodeID),
+	)
+
+	return instantiated.CodeID, instantiated.Address, nil
+}
+
+// Upgrade uploads the new code of the contract, migrates the contract to it with the migrate message
+// and checks the contract info has the new code id. The signer must be the admin of the contract
+func (cd *ContractDeployer) Upgrade(address string, filepath string, msg []byte, gasPrice uint64) (UpgradeResult, error) {
+	codeID, storeTx, err := cd.upload(filepath, gasPrice)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+
+	migrateTx, err := cd.chainService.MigrateContract(cd.signer.Name, address, codeID, msg, gasPrice)
+	if err != nil {
+		return UpgradeResult{}, fmt.Errorf("error migrating contract %s: %w", address, err)
+	}
+	res, err := cd.chainService.DecodeTxResult(migrateTx)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+	migrated, err := res.Migrate(0)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+
+	info, err := cd.chainService.GetContractInfo(address)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+	if info.CodeID != codeID || migrated.CodeID != codeID {
+		return UpgradeResult{}, fmt.Errorf("contract %s has code %d after the migration to code %d", address, info.CodeID, codeID)
+	}
+
+	cd.log.Info("Contract migrated",
+		zap.String("contract_address", address),
+		zap.Uint64("code_id", codeID),
+	)
+	return UpgradeResult{
+		CodeID:        codeID,
+		StoreTxHash:   storeTx.Hash.String(),
+		MigrateTxHash: migrateTx.Hash.String(),
+		Data:          migrated.Data,
+	}, nil
+}
+
+// DeployManifest uploads the contracts of the manifest in dependency order and
+// instantiates the ones with an instantiate message, returns the deployed contracts by name
+func (cd *ContractDeployer) DeployManifest(m *Manifest, gasPrice uint64) (map[string]DeployedContract, error) {
+	return DeployManifestParallel(m, []*ContractDeployer{cd}, DeployOptions{GasPrice: gasPrice})
+}
+
+// deployContract uploads the contract and instantiates it if the spec has an instantiate message.
+// The code and the contract of the previous deployment are reused if they are still on chain,
+// the contract is instantiated again if one of its dependencies changed.
+// If the artifact changed and the spec has a migrate message, the contract is migrated to the new code instead.
+// With opts.Batch a new contract is stored and instantiated in one transaction if it fits.
+// Returns true if anything was deployed.
+func (cd *ContractDeployer) deployContract(job deployJob, opts DeployOptions) (DeployedContract, bool, error) {
+	spec, prev := job.spec, job.prev
+
+	checksum, err := ArtifactChecksum(spec.Artifact)
+	if err != nil {
+		return DeployedContract{}, false, fmt.Errorf("error reading artifact: %w", err)
+	}
+	deployed := DeployedContract{Name: spec.Name, Checksum: checksum}
+
+	codeReused := prev != nil && prev.Checksum == checksum && prev.CodeID != 0 && cd.codeOnChain(prev.CodeID, checksum)
+	if codeReused {
+		deployed.CodeID = prev.CodeID
+		deployed.StoreTxHash = prev.StoreTxHash
+		cd.log.Info("Code already uploaded, skipping", zap.String("name", spec.Name), zap.Uint64("code_id", deployed.CodeID))
+	}
+
+	if len(spec.Msg) == 0 {
+		if !codeReused {
+			codeID, txRes, err := cd.upload(spec.Artifact, opts.GasPrice)
+			if err != nil {
+				return deployed, false, err
+			}
+			deployed.CodeID = codeID
+			deployed.StoreTxHash = txRes.Hash.String()
+		}
+		cd.log.Info("Contract uploaded", zap.String("name", spec.Name), zap.Uint64("code_id", deployed.CodeID))
+		return deployed, !codeReused, nil
+	}
+
+	if codeReused && !job.depsChanged && prev.Address != "" && cd.contractOnChain(prev.Address, deployed.CodeID) {
+		deployed.Address = prev.Address
+		deployed.InstantiateTxHash = prev.InstantiateTxHash
+		deployed.MigrateTxHash = prev.MigrateTxHash
+		cd.log.Info("Contract already instantiated, skipping",
+			zap.String("name", spec.Name),
+			zap.String("contract_address", deployed.Address),
+		)
+		return deployed, false, nil
+	}
+
+	if !codeReused && len(spec.MigrateMsg) > 0 && !job.depsChanged &&
+		prev != nil && prev.Address != "" && cd.contractOnChain(prev.Address, prev.CodeID) {
+		return cd.upgradeContract(deployed, job, opts)
+	}
+
+	msg, instOpts, err := resolveInstantiate(spec, job.values)
+	if err != nil {
+		return deployed, false, err
+	}
+
+	if !codeReused && opts.Batch {
+		stored, err := cd.storeAndInstantiate(spec, msg, instOpts, checksum, opts.GasPrice)
+		switch {
+		case err == nil:
+			deployed.CodeID, deployed.Address = stored.CodeID, stored.Address
+			deployed.StoreTxHash, deployed.InstantiateTxHash = stored.StoreTxHash, stored.InstantiateTxHash
+			cd.log.Info("Contract deployed",
+				zap.String("name", spec.Name),
+				zap.Uint64("code_id", deployed.CodeID),
+				zap.String("contract_address", deployed.Address),
+			)
+			return deployed, true, nil
+		case errors.Is(err, ErrTxTooLarge):
+			cd.log.Info("Contract does not fit in one transaction, storing separately", zap.String("name", spec.Name), zap.Error(err))
+		default:
+			return deployed, false, err
+		}
+	}
+
+	if !codeReused {
+		codeID, txRes, err := cd.upload(spec.Artifact, opts.GasPrice)
+		if err != nil {
+			return deployed, false, err
+		}
+		deployed.CodeID = codeID
+		deployed.StoreTxHash = txRes.Hash.String()
+	}
+
+	txRes, err := cd.chainService.InstantiateContractWithOptions(cd.signer.Name, deployed.CodeID, msg, instOpts, opts.GasPrice)
+	if err != nil {
+		return deployed, false, err
+	}
+
+	instantiated, err := cd.instantiateResult(txRes, 0)
+	if err != nil {
+		return deployed, false, err
+	}
+	deployed.Address = instantiated.Address
+	deployed.InstantiateTxHash = txRes.Hash.String()
+
+	cd.log.Info("Contract deployed",
+		zap.String("name", spec.Name),
+		zap.Uint64("code_id", deployed.CodeID),
+		zap.String("contract_address", deployed.Address),
+	)
+	return deployed, true, nil
+}
+
+// upgradeContract uploads the new code of the contract of the previous deployment and migrates the contract
+func (cd *ContractDeployer) upgradeContract(deployed DeployedContract, job deployJob, opts DeployOptions) (DeployedContract, bool, error) {
+	msg, err := job.values.ResolveJSON(job.spec.MigrateMsg)
+	if err != nil {
+		return deployed, false, err
+	}
+
+	upgraded, err := cd.Upgrade(job.prev.Address, job.spec.Artifact, msg, opts.GasPrice)
+	if err != nil {
+		return deployed, false, err
+	}
+	deployed.CodeID = upgraded.CodeID
+	deployed.Address = job.prev.Address
+	deployed.StoreTxHash = upgraded.StoreTxHash
+	deployed.InstantiateTxHash = job.prev.InstantiateTxHash
+	deployed.MigrateTxHash = upgraded.MigrateTxHash
+
+	cd.log.Info("Contract upgraded",
+		zap.String("name", job.spec.Name),
+		zap.Uint64("previous_code_id", job.prev.CodeID),
+		zap.Uint64("code_id", deployed.CodeID),
+		zap.String("contract_address", deployed.Address),
+	)
+	return deployed, true, nil
+}
+
+// storeAndInstantiate stores and instantiates the contract in one transaction,
+// both tx hashes of the result are the hash of the batched transaction
+func (cd *ContractDeployer) storeAndInstantiate(
+	spec ContractSpec,
+	msg []byte,
+	opts InstantiateOptions,
+	checksum string,
+	gasPrice uint64,
+) (DeployedContract, error) {
+	txRes, err := cd.chainService.StoreAndInstantiateContract(cd.signer.Name, spec.Artifact, msg, opts, gasPrice)
+	if err != nil {
+		return DeployedContract{}, err
+	}
+
+	stored, err := cd.storeCodeResult(txRes, 0)
+	if err != nil {
+		return DeployedContract{}, err
+	}
+	instantiated, err := cd.instantiateResult(txRes, 1)
+	if err != nil {
+		return DeployedContract{}, err
+	}
+
+	if err := cd.verifyCode(stored.CodeID, checksum); err != nil {
+		return DeployedContract{}, fmt.Errorf("stored code does not match the artifact: %w", err)
+	}
+
+	return DeployedContract{
+		Name:              spec.Name,
+		CodeID:            stored.CodeID,
+		Address:           instantiated.Address,
+		Checksum:          checksum,
+		StoreTxHash:       txRes.Hash.String(),
+		InstantiateTxHash: txRes.Hash.String(),
+	}, nil
+}
+
+// resolveInstantiate resolves the placeholders of the instantiate message, label, admin and funds
+func resolveInstantiate(spec ContractSpec, values PlaceholderValues) ([]byte, InstantiateOptions, error) {
+	msg, err := values.ResolveJSON(spec.Msg)
+	if err != nil {
+		return nil, InstantiateOptions{}, err
+	}
+
+	opts := InstantiateOptions{Label: spec.Name}
+	if spec.Label != "" {
+		if opts.Label, err = values.ResolveString(spec.Label); err != nil {
+			return nil, opts, err
+		}
+	}
+	if opts.Admin, err = values.ResolveString(spec.Admin); err != nil {
+		return nil, opts, err
+	}
+	if spec.Funds != "" {
+		funds, err := values.ResolveString(spec.Funds)
+		if err != nil {
+			return nil, opts, err
+		}
+		if opts.Funds, err = sdk.ParseCoinsNormalized(funds); err != nil {
+			return nil, opts, fmt.Errorf("invalid funds: %w", err)
+		}
+	}
+	return msg, opts, nil
+}
+
+// codeOnChain checks the code id is stored on chain with the checksum as data hash
+func (cd *ContractDeployer) codeOnChain(codeID uint64, checksum string) bool {
+	if err := cd.verifyCode(codeID, checksum); err != nil {
+		cd.log.Info("Code is not on chain", zap.Uint64("code_id", codeID), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// verifyCode queries the stored code and compares its data hash with the artifact checksum
+func (cd *ContractDeployer) verifyCode(codeID uint64, checksum string) error {
+	res, err := cd.chainService.GetCode(codeID)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(res.DataHash.String(), checksum) {
+		return fmt.Errorf("code %d data hash %s, expected %s", codeID, res.DataHash.String(), checksum)
+	}
+	return nil
+}
+
+// contractOnChain checks the contract exists on chain and is instantiated from the code id
+func (cd *ContractDeployer) contractOnChain(address string, codeID uint64) bool {
+	info, err := cd.chainService.GetContractInfo(address)
+	if err != nil {
+		cd.log.Info("Contract not found on chain", zap.String("contract_address", address), zap.Error(err))
+		return false
+	}
+
+	return info.CodeID == codeID
+}
+
+// storeCodeResult decodes the result of the store code message with the given index of the transaction
+func (cd *ContractDeployer) storeCodeResult(txRes *coretypes.ResultTx, msgIndex int) (StoreCodeResult, error) {
+	res, err := cd.chainService.DecodeTxResult(txRes)
+	if err != nil {
+		return StoreCodeResult{}, err
+	}
+	return res.StoreCode(msgIndex)
+}
+
+// instantiateResult decodes the result of the instantiate message with the given index of the transaction
+func (cd *ContractDeployer) instantiateResult(txRes *coretypes.ResultTx, msgIndex int) (InstantiateResult, error) {
+	res, err := cd.chainService.DecodeTxResult(txRes)
+	if err != nil {
+		return InstantiateResult{}, err
+	}
+	return res.Instantiate(msgIndex)
+}
@@ -0,0 +1,290 @@
+package chainclient
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	cd "github.com/consideritdone/landslide-runner/chainclient/codec"
+)
+
+type (
+	// TxResult - events and message responses of a committed transaction by message index
+	TxResult struct {
+		Hash string
+		Msgs []MsgResult
+	}
+
+	// MsgResult - events emitted by a message and its response decoded from the tx data,
+	// Response is nil if the tx data has no responses
+	MsgResult struct {
+		Events   []abci.Event
+		Response proto.Message
+	}
+
+	// StoreCodeResult - result of MsgStoreCode
+	StoreCodeResult struct {
+		CodeID   uint64
+		Checksum string
+	}
+
+	// InstantiateResult - result of MsgInstantiateContract and MsgInstantiateContract2
+	InstantiateResult struct {
+		Address string
+		CodeID  uint64
+		Data    []byte
+	}
+
+	// MigrateResult - result of MsgMigrateContract
+	MigrateResult struct {
+		Address string
+		CodeID  uint64
+		Data    []byte
+	}
+
+	// ExecuteResult - attributes of the wasm events and the data returned by the contract,
+	// the attributes of contracts called by submessages are included
+	ExecuteResult struct {
+		Attributes []abci.EventAttribute
+		Data       []byte
+	}
+)
+
+// DecodeTxResult - decode the message responses of the tx data and
+// split the events of the transaction by the index of the message which emitted them
+func DecodeTxResult(codec cd.Codec, res *coretypes.ResultTx) (*TxResult, error) {
+	if res.TxResult.Code != 0 {
+		return nil, fmt.Errorf("transaction %s failed with code %d: %s", res.Hash, res.TxResult.Code, res.TxResult.Log)
+	}
+
+	var msgData sdk.TxMsgData
+	if err := codec.GetMarshaler().Unmarshal(res.TxResult.Data, &msgData); err != nil {
+		return nil, fmt.Errorf("error decoding tx data: %w", err)
+	}
+
+	msgCount := len(msgData.MsgResponses)
+	if msgCount == 0 {
+		// the message count is taken from the events if the tx data is empty
+		msgCount = eventsMsgCount(res.TxResult.GetEvents())
+	}
+	events, err := MsgEvents(res, msgCount)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TxResult{Hash: res.Hash.String(), Msgs: make([]MsgResult, msgCount)}
+	for i := range result.Msgs {
+		result.Msgs[i].Events = events[i]
+	}
+	for i, msgResponse := range msgData.MsgResponses {
+		response, err := codec.GetInterfaceRegistry().Resolve(msgResponse.TypeUrl)
+		if err != nil {
+			return nil, fmt.Errorf("unknown response %s of message %d: %w", msgResponse.TypeUrl, i, err)
+		}
+		if err := codec.GetMarshaler().Unmarshal(msgResponse.Value, response); err != nil {
+			return nil, fmt.Errorf("error decoding response %s of message %d: %w", msgResponse.TypeUrl, i, err)
+		}
+		result.Msgs[i].Response = response
+	}
+	return result, nil
+}
+
+// DecodeTxResult - decode the transaction result by the codec of the client
+func (s *ChainService) DecodeTxResult(res *coretypes.ResultTx) (*TxResult, error) {
+	return DecodeTxResult(s.client.Codec, res)
+}
+
+// Msg - result of the message with the given index
+func (r *TxResult) Msg(index int) (MsgResult, error) {
+	if index < 0 || index >= len(r.Msgs) {
+		return MsgResult{}, fmt.Errorf("message index %d out of range, transaction %s has %d messages", index, r.Hash, len(r.Msgs))
+	}
+	return r.Msgs[index], nil
+}
+
+// StoreCode - result of the MsgStoreCode with the given index
+func (r *TxResult) StoreCode(index int) (StoreCodeResult, error) {
+	msg, err := r.Msg(index)
+	if err != nil {
+		return StoreCodeResult{}, err
+	}
+
+	if response, ok := msg.Response.(*wasmtypes.MsgStoreCodeResponse); ok {
+		return StoreCodeResult{CodeID: response.CodeID, Checksum: hex.EncodeToString(response.Checksum)}, nil
+	}
+
+	event, ok := msg.Event(wasmtypes.EventTypeStoreCode)
+	if !ok {
+		return StoreCodeResult{}, fmt.Errorf("message %d of transaction %s stored no code", index, r.Hash)
+	}
+	codeID, err := eventCodeID(event)
+	if err != nil {
+		return StoreCodeResult{}, err
+	}
+	checksum, _ := EventAttributeValue(event, wasmtypes.AttributeKeyChecksum)
+	return StoreCodeResult{CodeID: codeID, Checksum: checksum}, nil
+}
+
+// Instantiate - result of the instantiate message with the given index,
+// the code id is taken from the instantiate event of the contract
+func (r *TxResult) Instantiate(index int) (InstantiateResult, error) {
+	msg, err := r.Msg(index)
+	if err != nil {
+		return InstantiateResult{}, err
+	}
+
+	var result InstantiateResult
+	switch response := msg.Response.(type) {
+	case *wasmtypes.MsgInstantiateContractResponse:
+		result.Address, result.Data = response.Address, response.Data
+	case *wasmtypes.MsgInstantiateContract2Response:
+		result.Address, result.Data = response.Address, response.Data
+	case nil:
+	default:
+		return InstantiateResult{}, fmt.Errorf("message %d of transaction %s is not an instantiate message: %s",
+			index, r.Hash, proto.MessageName(response))
+	}
+
+	// submessages may instantiate other contracts, the first event is the one of the message
+	for _, event := range msg.Events {
+		if event.Type != wasmtypes.EventTypeInstantiate {
+			continue
+		}
+		address, _ := EventAttributeValue(event, wasmtypes.AttributeKeyContractAddr)
+		if result.Address != "" && address != result.Address {
+			continue
+		}
+		result.Address = address
+		if result.CodeID, err = eventCodeID(event); err != nil {
+			return InstantiateResult{}, err
+		}
+		return result, nil
+	}
+	return InstantiateResult{}, fmt.Errorf("message %d of transaction %s instantiated no contract", index, r.Hash)
+}
+
+// Execute - result of the MsgExecuteContract with the given index
+func (r *TxResult) Execute(index int) (ExecuteResult, error) {
+	msg, err := r.Msg(index)
+	if err != nil {
+		return ExecuteResult{}, err
+	}
+
+	var result ExecuteResult
+	switch response := msg.Response.(type) {
+	case *wasmtypes.MsgExecuteContractResponse:
+		result.Data = response.Data
+	case nil:
+	default:
+		return ExecuteResult{}, fmt.Errorf("message %d of transaction %s is not an execute message: %s",
+			index, r.Hash, proto.MessageName(response))
+	}
+
+	executed := false
+	for _, event := range msg.Events {
+		switch event.Type {
+		case wasmtypes.EventTypeExecute:
+			executed = true
+		case wasmtypes.WasmModuleEventType:
+			for _, attr := range event.Attributes {
+				if attr.Key != msgIndexAttribute {
+					result.Attributes = append(result.Attributes, attr)
+				}
+			}
+		}
+	}
+	if !executed {
+		return ExecuteResult{}, fmt.Errorf("message %d of transaction %s executed no contract", index, r.Hash)
+	}
+	return result, nil
+}
+
+// Migrate - result of the MsgMigrateContract with the given index
+func (r *TxResult) Migrate(index int) (MigrateResult, error) {
+	msg, err := r.Msg(index)
+	if err != nil {
+		return MigrateResult{}, err
+	}
+
+	var result MigrateResult
+	switch response := msg.Response.(type) {
+	case *wasmtypes.MsgMigrateContractResponse:
+		result.Data = response.Data
+	case nil:
+	default:
+		return MigrateResult{}, fmt.Errorf("message %d of transaction %s is not a migrate message: %s",
+			index, r.Hash, proto.MessageName(response))
+	}
+
+	event, ok := msg.Event(wasmtypes.EventTypeMigrate)
+	if !ok {
+		return MigrateResult{}, fmt.Errorf("message %d of transaction %s migrated no contract", index, r.Hash)
+	}
+	result.Address, _ = EventAttributeValue(event, wasmtypes.AttributeKeyContractAddr)
+	if result.CodeID, err = eventCodeID(event); err != nil {
+		return MigrateResult{}, err
+	}
+	return result, nil
+}
+
+// Event - first event of the message with the given type
+func (m MsgResult) Event(eventType string) (abci.Event, bool) {
+	for _, event := range m.Events {
+		if event.Type == eventType {
+			return event, true
+		}
+	}
+	return abci.Event{}, false
+}
+
+// Attribute - value of the first attribute with the given key
+func (r ExecuteResult) Attribute(key string) (string, bool) {
+	for _, attr := range r.Attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// EventAttributeValue - value of the first attribute of the event with the given key
+func EventAttributeValue(event abci.Event, key string) (string, bool) {
+	for _, attr := range event.Attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// eventCodeID - parse the code id attribute of the event
+func eventCodeID(event abci.Event) (uint64, error) {
+	rawCodeID, ok := EventAttributeValue(event, wasmtypes.AttributeKeyCodeID)
+	if !ok {
+		return 0, fmt.Errorf("event %s has no %s", event.Type, wasmtypes.AttributeKeyCodeID)
+	}
+	codeID, err := strconv.ParseUint(rawCodeID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing code id %q of event %s: %w", rawCodeID, event.Type, err)
+	}
+	return codeID, nil
+}
+
+// eventsMsgCount - number of messages which emitted the events, by the highest message index
+func eventsMsgCount(events []abci.Event) int {
+	count := 0
+	for _, event := range events {
+		if value, ok := EventAttributeValue(event, msgIndexAttribute); ok {
+			if index, err := strconv.Atoi(value); err == nil && index >= count {
+				count = index + 1
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,117 @@
+package chainclient
+
+import (
+	"testing"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	cd "github.com/consideritdone/landslide-runner/chainclient/codec"
+)
+
+func TestDecodeTxResult(t *testing.T) {
+	codec := cd.NewCodec()
+	event := func(typ string, attrs ...string) abci.Event {
+		e := abci.Event{Type: typ}
+		for i := 0; i < len(attrs); i += 2 {
+			e.Attributes = append(e.Attributes, abci.EventAttribute{Key: attrs[i], Value: attrs[i+1]})
+		}
+		return e
+	}
+	txData := func(responses ...proto.Message) []byte {
+		msgData := sdk.TxMsgData{}
+		for _, response := range responses {
+			anyResponse, err := codectypes.NewAnyWithValue(response)
+			if err != nil {
+				t.Fatal(err)
+			}
+			msgData.MsgResponses = append(msgData.MsgResponses, anyResponse)
+		}
+		data, err := codec.GetMarshaler().Marshal(&msgData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	res := &coretypes.ResultTx{TxResult: abci.ExecTxResult{
+		Data: txData(
+			&wasmtypes.MsgStoreCodeResponse{CodeID: 7, Checksum: []byte{0xab, 0xcd}},
+			&wasmtypes.MsgInstantiateContractResponse{Address: "wasm1contract", Data: []byte("init")},
+			&wasmtypes.MsgExecuteContractResponse{Data: []byte("exec")},
+			&wasmtypes.MsgMigrateContractResponse{Data: []byte("migrate")},
+		),
+		Events: []abci.Event{
+			event("tx", "fee", "100stake"),
+			event("store_code", "code_id", "7", "code_checksum", "abcd", msgIndexAttribute, "0"),
+			event("instantiate", "_contract_address", "wasm1contract", "code_id", "7", msgIndexAttribute, "1"),
+			event("instantiate", "_contract_address", "wasm1sub", "code_id", "3", msgIndexAttribute, "1"),
+			event("execute", "_contract_address", "wasm1contract", msgIndexAttribute, "2"),
+			event("wasm", "_contract_address", "wasm1contract", "action", "register", msgIndexAttribute, "2"),
+			event("migrate", "code_id", "8", "_contract_address", "wasm1contract", msgIndexAttribute, "3"),
+		},
+	}}
+
+	result, err := DecodeTxResult(codec, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Msgs) != 4 {
+		t.Fatalf("unexpected message count %d", len(result.Msgs))
+	}
+
+	stored, err := result.StoreCode(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.CodeID != 7 || stored.Checksum != "abcd" {
+		t.Fatalf("unexpected store code result %+v", stored)
+	}
+
+	instantiated, err := result.Instantiate(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instantiated.Address != "wasm1contract" || instantiated.CodeID != 7 || string(instantiated.Data) != "init" {
+		t.Fatalf("unexpected instantiate result %+v", instantiated)
+	}
+
+	executed, err := result.Execute(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action, _ := executed.Attribute("action"); action != "register" || string(executed.Data) != "exec" {
+		t.Fatalf("unexpected execute result %+v", executed)
+	}
+
+	migrated, err := result.Migrate(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated.Address != "wasm1contract" || migrated.CodeID != 8 || string(migrated.Data) != "migrate" {
+		t.Fatalf("unexpected migrate result %+v", migrated)
+	}
+
+	if _, err := result.Instantiate(0); err == nil {
+		t.Fatal("expected error for a store code message")
+	}
+	if _, err := result.Execute(4); err == nil {
+		t.Fatal("expected error for message index out of range")
+	}
+
+	// without the tx data the results are taken from the events
+	res.TxResult.Data = nil
+	if result, err = DecodeTxResult(codec, res); err != nil {
+		t.Fatal(err)
+	}
+	if stored, err = result.StoreCode(0); err != nil || stored.CodeID != 7 {
+		t.Fatalf("unexpected store code result %+v: %v", stored, err)
+	}
+	if instantiated, err = result.Instantiate(1); err != nil || instantiated.Address != "wasm1contract" {
+		t.Fatalf("unexpected instantiate result %+v: %v", instantiated, err)
+	}
+}
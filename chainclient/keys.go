@@ -0,0 +1,233 @@
+package chainclient
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdked25519 "github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+
+	"github.com/consideritdone/landslide-runner/chainclient/crypto/ethsecp256k1"
+)
+
+// KeyType - signing algorithm of the account key
+type KeyType string
+
+// Supported key types
+const (
+	// KeyTypeSecp256k1 - the default cosmos key
+	KeyTypeSecp256k1 KeyType = "secp256k1"
+	// KeyTypeEthSecp256k1 - the key of the Ethermint-style chains with an Ethereum address
+	KeyTypeEthSecp256k1 KeyType = ethsecp256k1.KeyType
+	// KeyTypeEd25519 - ed25519 key derived with SLIP-10, every path component must be hardened
+	KeyTypeEd25519 KeyType = "ed25519"
+)
+
+// Default HD paths of the key types
+const (
+	EthHDPath     = "m/44'/60'/0'/0/0"
+	Ed25519HDPath = "m/44'/118'/0'/0'/0'"
+)
+
+// AccountOptions - key type and HD path of the account key.
+// The zero value derives a secp256k1 key on the cosmos path m/44'/118'/0'/0/0.
+type AccountOptions struct {
+	KeyType KeyType
+	HDPath  string
+}
+
+var (
+	// ethSecp256k1Algo - secp256k1 derivation with the eth_secp256k1 key
+	ethSecp256k1Algo = signingAlgo{
+		name:     hd.PubKeyType(ethsecp256k1.KeyType),
+		derive:   hd.Secp256k1.Derive(),
+		generate: func(bz []byte) cryptotypes.PrivKey { return ethsecp256k1.GenerateKey(bz) },
+	}
+
+	// ed25519Algo - SLIP-10 ed25519 derivation
+	ed25519Algo = signingAlgo{
+		name:     hd.Ed25519Type,
+		derive:   deriveEd25519,
+		generate: func(bz []byte) cryptotypes.PrivKey { return &sdked25519.PrivKey{Key: ed25519.NewKeyFromSeed(bz)} },
+	}
+)
+
+// keyringOptions - the keyring supports the key types of the chain client
+func keyringOptions(options *keyring.Options) {
+	options.SupportedAlgos = keyring.SigningAlgoList{hd.Secp256k1, ethSecp256k1Algo, ed25519Algo}
+}
+
+// signingAlgo - keyring signature algorithm
+type signingAlgo struct {
+	name     hd.PubKeyType
+	derive   hd.DeriveFn
+	generate hd.GenerateFn
+}
+
+func (a signingAlgo) Name() hd.PubKeyType     { return a.name }
+func (a signingAlgo) Derive() hd.DeriveFn     { return a.derive }
+func (a signingAlgo) Generate() hd.GenerateFn { return a.generate }
+
+// SigningAlgo - the keyring algorithm and the HD path of the account options
+func SigningAlgo(opts AccountOptions) (keyring.SignatureAlgo, string, error) {
+	var (
+		algo keyring.SignatureAlgo
+		path string
+	)
+	switch opts.KeyType {
+	case "", KeyTypeSecp256k1:
+		algo, path = hd.Secp256k1, sdk.FullFundraiserPath
+	case KeyTypeEthSecp256k1:
+		algo, path = ethSecp256k1Algo, EthHDPath
+	case KeyTypeEd25519:
+		algo, path = ed25519Algo, Ed25519HDPath
+	default:
+		return nil, "", fmt.Errorf("unsupported key type %q", opts.KeyType)
+	}
+
+	if opts.HDPath != "" {
+		path = opts.HDPath
+	}
+	return algo, path, nil
+}
+
+// deriveEd25519 derives the ed25519 seed of the hardened path with SLIP-10
+func deriveEd25519(mnemonic, bip39Passphrase, hdPath string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	components := strings.Split(strings.TrimPrefix(hdPath, "m/"), "/")
+	if hdPath == "" || hdPath == "m" {
+		components = nil
+	}
+	for _, component := range components {
+		index, hardened := strings.CutSuffix(component, "'")
+		if !hardened {
+			return nil, fmt.Errorf("ed25519 path %s: component %s must be hardened", hdPath, component)
+		}
+		i, err := strconv.ParseUint(index, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("ed25519 path %s: invalid component %s", hdPath, component)
+		}
+
+		data := make([]byte, 0, 37)
+		data = append(data, 0)
+		data = append(data, key...)
+		data = binary.BigEndian.AppendUint32(data, uint32(i)|1<<31)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+
+	return key, nil
+}
+
+// AddAccountWithOptions - add account with the key type and the HD path of the options,
+// the account number and sequence are loaded from the chain before the first transaction.
+func (c *ChainClient) AddAccountWithOptions(name, mnemonic string, opts AccountOptions) error {
+	return c.addAccountWithOptions(AccountInfo{Name: name, Mnemonic: mnemonic}, opts)
+}
+
+// AddOfflineAccountWithOptions - add account with the key type and the HD path of the options
+// and the known account number and sequence.
+func (c *ChainClient) AddOfflineAccountWithOptions(name, mnemonic string, sequence, number uint64, opts AccountOptions) error {
+	return c.addAccountWithOptions(AccountInfo{
+		Name:     name,
+		Mnemonic: mnemonic,
+		Sequence: sequence,
+		Number:   number,
+		Loaded:   true,
+	}, opts)
+}
+
+// AddMultisigAccount - add the threshold multisig account of the keyring keys,
+// the account number and sequence are loaded from the chain before the first transaction.
+// The transactions are signed by all the keys, see SetMultisigSigners.
+func (c *ChainClient) AddMultisigAccount(name string, threshold int, keyNames []string) error {
+	return c.addMultisigAccount(AccountInfo{Name: name}, threshold, keyNames)
+}
+
+// AddOfflineMultisigAccount - add the threshold multisig account of the keyring keys
+// with the known account number and sequence.
+func (c *ChainClient) AddOfflineMultisigAccount(name string, threshold int, keyNames []string, sequence, number uint64) error {
+	return c.addMultisigAccount(AccountInfo{
+		Name:     name,
+		Sequence: sequence,
+		Number:   number,
+		Loaded:   true,
+	}, threshold, keyNames)
+}
+
+// addMultisigAccount - save the multisig key and add the account signed by all the keys
+func (c *ChainClient) addMultisigAccount(acc AccountInfo, threshold int, keyNames []string) error {
+	name := acc.Name
+	pubKey, err := MultisigPubKey(c.keyring, threshold, keyNames)
+	if err != nil {
+		return err
+	}
+
+	record, err := c.keyring.SaveMultisig(name, pubKey)
+	if err != nil {
+		return fmt.Errorf("error saving multisig key %s: %w", name, err)
+	}
+
+	addr, err := record.GetAddress()
+	if err != nil {
+		return fmt.Errorf("error getting address of multisig key %s: %w", name, err)
+	}
+
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	acc.Address = addr.String()
+	acc.MultisigSigners = append([]string{}, keyNames...)
+	c.signerAccounts[name] = acc
+	return nil
+}
+
+// MultisigPubKey - the threshold multisig public key of the keyring keys.
+// The public keys are sorted by address like `keys add --multisig` does,
+// so the address does not depend on the order of the names.
+func MultisigPubKey(kr keyring.Keyring, threshold int, keyNames []string) (cryptotypes.PubKey, error) {
+	if threshold < 1 || threshold > len(keyNames) {
+		return nil, fmt.Errorf("invalid threshold %d of %d keys", threshold, len(keyNames))
+	}
+
+	pubKeys := make([]cryptotypes.PubKey, 0, len(keyNames))
+	for _, keyName := range keyNames {
+		record, err := kr.Key(keyName)
+		if err != nil {
+			return nil, fmt.Errorf("key %s not found: %w", keyName, err)
+		}
+		pubKey, err := record.GetPubKey()
+		if err != nil {
+			return nil, fmt.Errorf("error getting public key of %s: %w", keyName, err)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i].Address(), pubKeys[j].Address()) < 0
+	})
+
+	return multisig.NewLegacyAminoPubKey(threshold, pubKeys), nil
+}
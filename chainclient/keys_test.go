@@ -0,0 +1,145 @@
+package chainclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"go.uber.org/zap"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestEthSecp256k1Account(t *testing.T) {
+	client := NewChainClient(300000, DefaultPrefix, DefaultChainID, DefaultDenom, zap.NewNop())
+	err := client.AddOfflineAccountWithOptions("eth", testMnemonic, 0, 7, AccountOptions{KeyType: KeyTypeEthSecp256k1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, _ := client.GetAccount("eth")
+
+	// the first MetaMask address of the mnemonic
+	_, addr, err := bech32.DecodeAndConvert(acc.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(addr); got != strings.ToLower("9858EfFD232B4033E47d90003D41EC34EcaEda94") {
+		t.Fatalf("unexpected eth address %s", got)
+	}
+
+	sig, pubKey, err := client.Keyring().Sign("eth", []byte("sign bytes"), signing.SignMode_SIGN_MODE_DIRECT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sig) != 65 || !pubKey.VerifySignature([]byte("sign bytes"), sig) || pubKey.VerifySignature([]byte("other"), sig) {
+		t.Fatal("invalid eth_secp256k1 signature")
+	}
+
+	msg, err := client.DecodeMsgJSON([]byte(fmt.Sprintf(
+		`{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":%q,"to_address":%q,"amount":[{"denom":"stake","amount":"1"}]}`,
+		acc.Address, acc.Address,
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := client.GenerateTx([]sdk.Msg{msg}, 200000, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := client.SignTxJSON(unsigned, "eth", acc.Number, acc.Sequence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(signed), "/ethermint.crypto.v1.ethsecp256k1.PubKey") {
+		t.Fatalf("signer public key is not eth_secp256k1: %s", signed)
+	}
+
+	decoded, err := client.Codec.GetTxConfig().TxJSONDecoder()(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigs, err := decoded.(authsigning.SigVerifiableTx).GetSignaturesV2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sigs) != 1 || !bytes.Equal(sigs[0].PubKey.Address(), addr) {
+		t.Fatal("unexpected signer of the decoded tx")
+	}
+}
+
+func TestEd25519Account(t *testing.T) {
+	client := NewChainClient(300000, DefaultPrefix, DefaultChainID, DefaultDenom, zap.NewNop())
+	if err := client.AddAccountWithOptions("ed", testMnemonic, AccountOptions{KeyType: KeyTypeEd25519}); err != nil {
+		t.Fatal(err)
+	}
+	err := client.AddAccountWithOptions("ed2", testMnemonic, AccountOptions{KeyType: KeyTypeEd25519, HDPath: "m/44'/118'/1'/0'/0'"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed, _ := client.GetAccount("ed")
+	ed2, _ := client.GetAccount("ed2")
+	if ed.Address == ed2.Address {
+		t.Fatal("different HD paths derived the same address")
+	}
+
+	record, err := client.Keyring().Key("ed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey, _ := record.GetPubKey()
+	if pubKey.Type() != "ed25519" {
+		t.Fatalf("unexpected key type %s", pubKey.Type())
+	}
+
+	err = client.AddAccountWithOptions("ed3", testMnemonic, AccountOptions{KeyType: KeyTypeEd25519, HDPath: "m/44'/118'/0'/0/0"})
+	if err == nil {
+		t.Fatal("expected error for a non-hardened ed25519 path")
+	}
+}
+
+func TestMultisigAccount(t *testing.T) {
+	client := NewChainClient(300000, DefaultPrefix, DefaultChainID, DefaultDenom, zap.NewNop())
+	client.AddAccount("user1", User1Mnemonic)
+	client.AddAccount("user2", User2Mnemonic)
+	if err := client.AddAccountWithOptions("user3", testMnemonic, AccountOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.AddMultisigAccount("multi", 2, []string{"user1", "user2", "user3"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddMultisigAccount("multi-reversed", 2, []string{"user3", "user2", "user1"}); err != nil {
+		t.Fatal(err)
+	}
+	multi, _ := client.GetAccount("multi")
+	reversed, _ := client.GetAccount("multi-reversed")
+	if multi.Address != reversed.Address {
+		t.Fatal("multisig address depends on the order of the keys")
+	}
+
+	if err := client.AddMultisigAccount("invalid", 4, []string{"user1", "user2", "user3"}); err == nil {
+		t.Fatal("expected error for a threshold above the number of keys")
+	}
+}
+
+func TestSetPrefixes(t *testing.T) {
+	if err := SetPrefixes(DefaultPrefix); err != nil {
+		t.Fatal(err)
+	}
+	// the sealed config keeps the first prefix
+	if err := SetPrefixes(DefaultPrefix); err != nil {
+		t.Fatalf("same prefix: %v", err)
+	}
+	if err := SetPrefixes("osmo"); err == nil {
+		t.Fatal("expected error for a different prefix")
+	}
+	if prefix := sdk.GetConfig().GetBech32AccountAddrPrefix(); prefix != DefaultPrefix {
+		t.Fatalf("prefix %s, expected %s", prefix, DefaultPrefix)
+	}
+}
@@ -0,0 +1,14 @@
+package chainclient
+
+import "go.uber.org/zap"
+
+// Logger - logger used by the chain client, it is satisfied by *zap.Logger
+type Logger interface {
+	Debug(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+	Fatal(msg string, fields ...zap.Field)
+}
+
+var _ Logger = &zap.Logger{}
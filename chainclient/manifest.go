@@ -0,0 +1,337 @@
+package chainclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Placeholder namespaces which are not contract names
+const (
+	namespaceSigner = "signer"
+	namespaceChain  = "chain"
+	namespaceVars   = "vars"
+	namespaceTime   = "time"
+)
+
+type (
+	// Manifest - declarative description of the contracts to deploy.
+	Manifest struct {
+		Vars      map[string]string `json:"vars,omitempty"`
+		Contracts []ContractSpec    `json:"contracts"`
+	}
+
+	// ContractSpec - contract to upload and, if Msg is set, to instantiate.
+	// MigrateMsg migrates the deployed contract when its artifact changes, the contract needs an Admin.
+	// Msg, MigrateMsg, Funds, Label and Admin may contain placeholders
+	// resolved from the outputs of the contracts listed in DependsOn.
+	ContractSpec struct {
+		Name       string          `json:"name"`
+		Artifact   string          `json:"artifact"`
+		Msg        json.RawMessage `json:"msg,omitempty"`
+		MigrateMsg json.RawMessage `json:"migrate_msg,omitempty"`
+		Funds      string          `json:"funds,omitempty"`
+		Label      string          `json:"label,omitempty"`
+		Admin      string          `json:"admin,omitempty"`
+		DependsOn  []string        `json:"depends_on,omitempty"`
+		Skip       bool            `json:"skip,omitempty"`
+	}
+
+	// DeployedContract - outputs of the deployed contract.
+	DeployedContract struct {
+		Name              string `json:"name"`
+		CodeID            uint64 `json:"code_id"`
+		Address           string `json:"address,omitempty"`
+		Checksum          string `json:"checksum"`
+		StoreTxHash       string `json:"store_tx_hash,omitempty"`
+		InstantiateTxHash string `json:"instantiate_tx_hash,omitempty"`
+		MigrateTxHash     string `json:"migrate_tx_hash,omitempty"`
+	}
+
+	// UpgradeResult - new code of the migrated contract
+	UpgradeResult struct {
+		CodeID        uint64
+		StoreTxHash   string
+		MigrateTxHash string
+		Data          []byte
+	}
+)
+
+// LoadManifest - load the YAML or JSON deployment manifest.
+// Relative artifact paths are resolved against the manifest directory.
+func LoadManifest(path string) (*Manifest, error) {
+	m := &Manifest{}
+	if err := UnmarshalFile(path, m); err != nil {
+		return nil, fmt.Errorf("error loading manifest: %w", err)
+	}
+
+	for i := range m.Contracts {
+		if m.Contracts[i].Artifact != "" && !filepath.IsAbs(m.Contracts[i].Artifact) {
+			m.Contracts[i].Artifact = filepath.Join(filepath.Dir(path), m.Contracts[i].Artifact)
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate - check the contract names are unique, the dependencies exist and
+// the placeholders only refer to the contracts listed in depends_on.
+func (m *Manifest) Validate() error {
+	specs := make(map[string]ContractSpec, len(m.Contracts))
+	for _, spec := range m.Contracts {
+		switch spec.Name {
+		case "":
+			return errors.New("contract without name")
+		case namespaceSigner, namespaceChain, namespaceVars, namespaceTime:
+			return fmt.Errorf("contract name %q is reserved", spec.Name)
+		}
+		if _, ok := specs[spec.Name]; ok {
+			return fmt.Errorf("contract %q is declared twice", spec.Name)
+		}
+		if spec.Artifact == "" {
+			return fmt.Errorf("contract %q has no artifact", spec.Name)
+		}
+		if len(spec.MigrateMsg) > 0 && len(spec.Msg) == 0 {
+			return fmt.Errorf("contract %q has a migrate message but is not instantiated", spec.Name)
+		}
+		specs[spec.Name] = spec
+	}
+
+	for _, spec := range m.Contracts {
+		deps := make(map[string]struct{}, len(spec.DependsOn))
+		for _, dep := range spec.DependsOn {
+			depSpec, ok := specs[dep]
+			switch {
+			case !ok:
+				return fmt.Errorf("contract %q depends on unknown contract %q", spec.Name, dep)
+			case dep == spec.Name:
+				return fmt.Errorf("contract %q depends on itself", spec.Name)
+			case depSpec.Skip && !spec.Skip:
+				return fmt.Errorf("contract %q depends on skipped contract %q", spec.Name, dep)
+			}
+			deps[dep] = struct{}{}
+		}
+
+		for _, ref := range spec.placeholders() {
+			namespace, key := ref[1], ref[2]
+			switch namespace {
+			case namespaceSigner, namespaceChain, namespaceVars, namespaceTime:
+				continue
+			}
+			if _, ok := deps[namespace]; !ok {
+				return fmt.Errorf("contract %q refers to %q which is not in depends_on", spec.Name, ref[0])
+			}
+			if key == "address" && len(specs[namespace].Msg) == 0 {
+				return fmt.Errorf("contract %q refers to the address of %q which is not instantiated", spec.Name, namespace)
+			}
+		}
+	}
+
+	_, err := m.Order()
+	return err
+}
+
+// Order - sort the contracts which are not skipped by dependency,
+// independent contracts keep the manifest order.
+func (m *Manifest) Order() ([]ContractSpec, error) {
+	var (
+		order   []ContractSpec
+		done    = make(map[string]bool, len(m.Contracts))
+		pending []ContractSpec
+	)
+	for _, spec := range m.Contracts {
+		if !spec.Skip {
+			pending = append(pending, spec)
+		}
+	}
+
+	for len(pending) > 0 {
+		var next []ContractSpec
+		for _, spec := range pending {
+			ready := true
+			for _, dep := range spec.DependsOn {
+				if !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				order = append(order, spec)
+				done[spec.Name] = true
+			} else {
+				next = append(next, spec)
+			}
+		}
+
+		if len(next) == len(pending) {
+			names := make([]string, len(next))
+			for i, spec := range next {
+				names[i] = spec.Name
+			}
+			return nil, fmt.Errorf("dependency cycle between contracts %s", strings.Join(names, ", "))
+		}
+		pending = next
+	}
+
+	return order, nil
+}
+
+// placeholders - all placeholders used by the contract
+func (spec ContractSpec) placeholders() [][]string {
+	var refs [][]string
+	for _, s := range []string{string(spec.Msg), string(spec.MigrateMsg), spec.Funds, spec.Label, spec.Admin} {
+		refs = append(refs, placeholderRe.FindAllStringSubmatch(s, -1)...)
+	}
+	return refs
+}
+
+// PlaceholderValues - values the placeholders are resolved from.
+type PlaceholderValues struct {
+	Contracts map[string]DeployedContract
+	Vars      map[string]string
+	Signer    string
+	ChainID   string
+	Denom     string
+	Now       time.Time
+}
+
+// lookup - resolve the placeholder to a string or a number
+func (v PlaceholderValues) lookup(p Placeholder) (interface{}, error) {
+	namespace, key, offset := p.Namespace, p.Key, p.Offset
+	if offset != "" && namespace != namespaceTime {
+		return nil, fmt.Errorf("offset is only supported for %s placeholders", namespaceTime)
+	}
+
+	switch namespace {
+	case namespaceSigner:
+		if key == "address" {
+			return v.Signer, nil
+		}
+	case namespaceChain:
+		switch key {
+		case "id":
+			return v.ChainID, nil
+		case "denom":
+			return v.Denom, nil
+		}
+	case namespaceVars:
+		if value, ok := v.Vars[key]; ok {
+			return value, nil
+		}
+		return nil, fmt.Errorf("variable %q is not set", key)
+	case namespaceTime:
+		t := v.Now
+		if offset != "" {
+			d, err := time.ParseDuration(offset)
+			if err != nil {
+				return nil, fmt.Errorf("invalid time offset: %w", err)
+			}
+			t = t.Add(d)
+		}
+		switch key {
+		case "unix":
+			return t.Unix(), nil
+		case "unix_milli":
+			return t.UnixMilli(), nil
+		case "unix_nano":
+			return t.UnixNano(), nil
+		}
+	default:
+		contract, ok := v.Contracts[namespace]
+		if !ok {
+			return nil, fmt.Errorf("contract %q is not deployed", namespace)
+		}
+		switch key {
+		case "address":
+			return contract.Address, nil
+		case "code_id":
+			return contract.CodeID, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown placeholder %s.%s", namespace, key)
+}
+
+// ResolveString - replace the placeholders in the string
+func (v PlaceholderValues) ResolveString(s string) (string, error) {
+	return ReplacePlaceholders(s, func(p Placeholder) (string, error) {
+		value, err := v.lookup(p)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprint(value), nil
+	})
+}
+
+// ResolveJSON - replace the placeholders in the JSON message.
+// A string which is a single placeholder takes the type of the value,
+// so {{ vault.code_id }} becomes a number, "| string" and "| number" override the type.
+func (v PlaceholderValues) ResolveJSON(msg json.RawMessage) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(msg))
+	dec.UseNumber()
+
+	var tree interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+
+	resolved, err := v.resolveValue(tree)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resolved)
+}
+
+func (v PlaceholderValues) resolveValue(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			resolved, err := v.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			value[k] = resolved
+		}
+		return value, nil
+	case []interface{}:
+		for i, item := range value {
+			resolved, err := v.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = resolved
+		}
+		return value, nil
+	case string:
+		ref := placeholderRe.FindStringSubmatch(value)
+		if ref == nil || ref[0] != value {
+			return v.ResolveString(value)
+		}
+
+		resolved, err := v.lookup(parsePlaceholder(ref))
+		if err != nil {
+			return nil, err
+		}
+		switch ref[4] {
+		case "string":
+			return fmt.Sprint(resolved), nil
+		case "number":
+			n := json.Number(fmt.Sprint(resolved))
+			if _, err := strconv.ParseFloat(n.String(), 64); err != nil {
+				return nil, fmt.Errorf("%s is not a number", ref[0])
+			}
+			return n, nil
+		}
+		return resolved, nil
+	default:
+		return value, nil
+	}
+}
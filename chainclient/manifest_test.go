@@ -0,0 +1,163 @@
+package chainclient
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testManifest = `
+vars:
+  symbol: LND
+contracts:
+  - name: token
+    artifact: artifacts/token.wasm
+    depends_on: [kernel]
+    msg:
+      symbol: "{{ vars.symbol }}"
+      kernel_address: "{{ kernel.address }}"
+      code_id: "{{ kernel.code_id }}"
+      code_id_string: "{{ kernel.code_id | string }}"
+      start_time: "{{ time.unix_nano + 24h | string }}"
+      owner: "owner {{ signer.address }}"
+  - name: kernel
+    artifact: artifacts/kernel.wasm
+    msg:
+      chain_name: "{{ chain.id }}"
+  - name: pair
+    artifact: artifacts/pair.wasm
+  - name: disabled
+    artifact: artifacts/disabled.wasm
+    skip: true
+`
+
+func writeManifest(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadManifest(t *testing.T) {
+	path := writeManifest(t, "deploy.yaml", testManifest)
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := m.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, spec := range order {
+		names = append(names, spec.Name)
+	}
+	if got := strings.Join(names, ","); got != "kernel,pair,token" {
+		t.Fatalf("unexpected order %s", got)
+	}
+
+	if want := filepath.Join(filepath.Dir(path), "artifacts/kernel.wasm"); order[0].Artifact != want {
+		t.Fatalf("artifact path %s, expected %s", order[0].Artifact, want)
+	}
+}
+
+func TestManifestValidate(t *testing.T) {
+	for name, content := range map[string]string{
+		"missing dependency": `
+contracts:
+  - name: token
+    artifact: token.wasm
+    msg: {kernel: "{{ kernel.address }}"}`,
+		"not in depends_on": `
+contracts:
+  - name: kernel
+    artifact: kernel.wasm
+    msg: {}
+  - name: token
+    artifact: token.wasm
+    msg: {kernel: "{{ kernel.address }}"}`,
+		"address of upload only": `
+contracts:
+  - name: vault
+    artifact: vault.wasm
+  - name: factory
+    artifact: factory.wasm
+    depends_on: [vault]
+    msg: {vault: "{{ vault.address }}"}`,
+		"cycle": `
+contracts:
+  - name: a
+    artifact: a.wasm
+    depends_on: [b]
+  - name: b
+    artifact: b.wasm
+    depends_on: [a]`,
+		"migrate upload only": `
+contracts:
+  - name: vault
+    artifact: vault.wasm
+    migrate_msg: {}`,
+		"depends on skipped": `
+contracts:
+  - name: a
+    artifact: a.wasm
+    skip: true
+  - name: b
+    artifact: b.wasm
+    depends_on: [a]`,
+	} {
+		if _, err := LoadManifest(writeManifest(t, "deploy.yaml", content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestResolveJSON(t *testing.T) {
+	m, err := LoadManifest(writeManifest(t, "deploy.yaml", testManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1000, 0)
+	values := PlaceholderValues{
+		Contracts: map[string]DeployedContract{
+			"kernel": {Name: "kernel", CodeID: 7, Address: "wasm1kernel"},
+		},
+		Vars:    m.Vars,
+		Signer:  "wasm1signer",
+		ChainID: DefaultChainID,
+		Denom:   DefaultDenom,
+		Now:     now,
+	}
+
+	msg, err := values.ResolveJSON(m.Contracts[0].Msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(msg, &got); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]interface{}{
+		"symbol":         "LND",
+		"kernel_address": "wasm1kernel",
+		"code_id":        float64(7),
+		"code_id_string": "7",
+		"start_time":     "87400000000000",
+		"owner":          "owner wasm1signer",
+	} {
+		if got[key] != want {
+			t.Errorf("%s = %v, expected %v", key, got[key], want)
+		}
+	}
+
+	if _, err := values.ResolveString("{{ vars.unknown }}"); err == nil {
+		t.Error("expected error for unknown variable")
+	}
+}
@@ -0,0 +1,72 @@
+package chainclient
+
+import (
+	"errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+// MigrateContract - migrate the contract to the code id with the json migrate message,
+// the signer must be the admin of the contract
+func (s *ChainService) MigrateContract(
+	signerName string,
+	contractAddress string,
+	codeID uint64,
+	msg []byte,
+	gasPrice uint64,
+) (*coretypes.ResultTx, error) {
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		s.log.Fatal("account not found", zap.String("signerName", signerName))
+		return nil, errors.New("account not found")
+	}
+
+	msgMigrate := &wasm.MsgMigrateContract{
+		Sender:   acc.Address,
+		Contract: contractAddress,
+		CodeID:   codeID,
+		Msg:      msg,
+	}
+
+	s.log.Info("MsgMigrateContract wasm contract", zap.String("contract", contractAddress), zap.Uint64("codeID", codeID))
+	return s.SendMsgs(signerName, []sdk.Msg{msgMigrate}, gasPrice)
+}
+
+// UpdateAdmin - set the new admin of the contract, the signer must be the current admin
+func (s *ChainService) UpdateAdmin(signerName string, contractAddress string, newAdmin string, gasPrice uint64) (*coretypes.ResultTx, error) {
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		s.log.Fatal("account not found", zap.String("signerName", signerName))
+		return nil, errors.New("account not found")
+	}
+
+	msgUpdate := &wasm.MsgUpdateAdmin{
+		Sender:   acc.Address,
+		NewAdmin: newAdmin,
+		Contract: contractAddress,
+	}
+
+	s.log.Info("MsgUpdateAdmin wasm contract", zap.String("contract", contractAddress), zap.String("newAdmin", newAdmin))
+	return s.SendMsgs(signerName, []sdk.Msg{msgUpdate}, gasPrice)
+}
+
+// ClearAdmin - remove the admin of the contract, the contract can not be migrated anymore.
+// The signer must be the current admin
+func (s *ChainService) ClearAdmin(signerName string, contractAddress string, gasPrice uint64) (*coretypes.ResultTx, error) {
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		s.log.Fatal("account not found", zap.String("signerName", signerName))
+		return nil, errors.New("account not found")
+	}
+
+	msgClear := &wasm.MsgClearAdmin{
+		Sender:   acc.Address,
+		Contract: contractAddress,
+	}
+
+	s.log.Info("MsgClearAdmin wasm contract", zap.String("contract", contractAddress))
+	return s.SendMsgs(signerName, []sdk.Msg{msgClear}, gasPrice)
+}
@@ -0,0 +1,221 @@
+package chainclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// multisigSignMode - the sign bytes of the legacy amino JSON mode do not depend on the signer infos,
+// so the keys sign before it is known which of them take part in the multisig signature
+const multisigSignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+
+// SetMultisigSigners - set the keyring keys signing the transactions of the multisig account,
+// by default all the keys of the multisig sign.
+func (c *ChainClient) SetMultisigSigners(name string, keyNames []string) error {
+	multiPub, err := c.multisigPubKey(name)
+	if err != nil {
+		return err
+	}
+	if len(keyNames) < int(multiPub.GetThreshold()) {
+		return fmt.Errorf("multisig %s requires %d signers, got %d", name, multiPub.GetThreshold(), len(keyNames))
+	}
+
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	acc, ok := c.signerAccounts[name]
+	if !ok {
+		return fmt.Errorf("account %s not found", name)
+	}
+	acc.MultisigSigners = keyNames
+	c.signerAccounts[name] = acc
+	return nil
+}
+
+// multisigPubKey - the multisig public key of the keyring record
+func (c *ChainClient) multisigPubKey(name string) (multisig.PubKey, error) {
+	record, err := c.keyring.Key(name)
+	if err != nil {
+		return nil, fmt.Errorf("key %s not found: %w", name, err)
+	}
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("error getting public key of %s: %w", name, err)
+	}
+	multiPub, ok := pubKey.(multisig.PubKey)
+	if !ok {
+		return nil, fmt.Errorf("key %s is not a multisig key", name)
+	}
+	return multiPub, nil
+}
+
+// multisigSignBytes - the legacy amino JSON sign bytes of the multisig account
+func (c *ChainClient) multisigSignBytes(txBuilder client.TxBuilder, multiPub multisig.PubKey, number, sequence uint64) ([]byte, error) {
+	signerData := authsigning.SignerData{
+		ChainID:       c.chainID,
+		AccountNumber: number,
+		Sequence:      sequence,
+		PubKey:        multiPub,
+		Address:       sdk.AccAddress(multiPub.Address()).String(),
+	}
+
+	return authsigning.GetSignBytesAdapter(
+		context.Background(),
+		c.Codec.GetTxConfig().SignModeHandler(),
+		multisigSignMode,
+		signerData,
+		txBuilder.GetTx(),
+	)
+}
+
+// signMultisig - sign the transaction with the signers of the multisig account
+// and set the combined signature
+func (c *ChainClient) signMultisig(txBuilder client.TxBuilder, acc AccountInfo, sequence uint64) error {
+	multiPub, err := c.multisigPubKey(acc.Name)
+	if err != nil {
+		return err
+	}
+	signBytes, err := c.multisigSignBytes(txBuilder, multiPub, acc.Number, sequence)
+	if err != nil {
+		return fmt.Errorf("error getting sign bytes: %w", err)
+	}
+
+	sigs := make([]signing.SignatureV2, 0, len(acc.MultisigSigners))
+	for _, keyName := range acc.MultisigSigners {
+		sig, pubKey, err := c.keyring.Sign(keyName, signBytes, multisigSignMode)
+		if err != nil {
+			return fmt.Errorf("sign tx error: %s", err)
+		}
+		sigs = append(sigs, signing.SignatureV2{
+			PubKey:   pubKey,
+			Data:     &signing.SingleSignatureData{SignMode: multisigSignMode, Signature: sig},
+			Sequence: sequence,
+		})
+	}
+
+	return setMultisigSignature(txBuilder, multiPub, sequence, sigs)
+}
+
+// setMultisigSignature - combine the signatures of the keys into the multisig signature of the transaction
+func setMultisigSignature(txBuilder client.TxBuilder, multiPub multisig.PubKey, sequence uint64, sigs []signing.SignatureV2) error {
+	multiSig := multisig.NewMultisig(len(multiPub.GetPubKeys()))
+	for _, sig := range sigs {
+		if err := multisig.AddSignatureV2(multiSig, sig, multiPub.GetPubKeys()); err != nil {
+			return fmt.Errorf("error adding signature: %w", err)
+		}
+	}
+	if len(multiSig.Signatures) < int(multiPub.GetThreshold()) {
+		return fmt.Errorf("multisig requires %d signatures, got %d", multiPub.GetThreshold(), len(multiSig.Signatures))
+	}
+
+	return txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   multiPub,
+		Data:     multiSig,
+		Sequence: sequence,
+	})
+}
+
+// multisigSimulateSignature - the multisig signature with the empty signatures of the signers,
+// the simulation consumes the gas of verifying each of them
+func (c *ChainClient) multisigSimulateSignature(acc AccountInfo, sequence uint64) (signing.SignatureV2, error) {
+	multiPub, err := c.multisigPubKey(acc.Name)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+
+	multiSig := multisig.NewMultisig(len(multiPub.GetPubKeys()))
+	for _, keyName := range acc.MultisigSigners {
+		record, err := c.keyring.Key(keyName)
+		if err != nil {
+			return signing.SignatureV2{}, fmt.Errorf("key %s not found: %w", keyName, err)
+		}
+		pubKey, err := record.GetPubKey()
+		if err != nil {
+			return signing.SignatureV2{}, fmt.Errorf("error getting public key of %s: %w", keyName, err)
+		}
+		err = multisig.AddSignatureFromPubKey(multiSig, &signing.SingleSignatureData{SignMode: multisigSignMode}, pubKey, multiPub.GetPubKeys())
+		if err != nil {
+			return signing.SignatureV2{}, err
+		}
+	}
+
+	return signing.SignatureV2{PubKey: multiPub, Data: multiSig, Sequence: sequence}, nil
+}
+
+// SignMultisigTxJSON - sign the JSON encoded transaction of the multisig account with one of its keys.
+// Returns the JSON encoded signature, the signatures of the keys are combined by CombineMultisigTxJSON.
+func (c *ChainClient) SignMultisigTxJSON(txJSON []byte, keyName, multisigName string, number, sequence uint64) ([]byte, error) {
+	txConfig := c.Codec.GetTxConfig()
+	txBuilder, err := c.wrapTxJSON(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	multiPub, err := c.multisigPubKey(multisigName)
+	if err != nil {
+		return nil, err
+	}
+	signBytes, err := c.multisigSignBytes(txBuilder, multiPub, number, sequence)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sign bytes: %w", err)
+	}
+
+	sig, pubKey, err := c.keyring.Sign(keyName, signBytes, multisigSignMode)
+	if err != nil {
+		return nil, fmt.Errorf("sign tx error: %s", err)
+	}
+
+	member := false
+	for _, pk := range multiPub.GetPubKeys() {
+		member = member || pk.Equals(pubKey)
+	}
+	if !member {
+		return nil, fmt.Errorf("key %s is not a key of the multisig %s", keyName, multisigName)
+	}
+
+	return txConfig.MarshalSignatureJSON([]signing.SignatureV2{{
+		PubKey:   pubKey,
+		Data:     &signing.SingleSignatureData{SignMode: multisigSignMode, Signature: sig},
+		Sequence: sequence,
+	}})
+}
+
+// CombineMultisigTxJSON - set the multisig signature combined from the JSON encoded signatures
+// of SignMultisigTxJSON. Returns the signed transaction encoded as JSON.
+func (c *ChainClient) CombineMultisigTxJSON(txJSON []byte, multisigName string, sequence uint64, signatures [][]byte) ([]byte, error) {
+	txConfig := c.Codec.GetTxConfig()
+	txBuilder, err := c.wrapTxJSON(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	multiPub, err := c.multisigPubKey(multisigName)
+	if err != nil {
+		return nil, err
+	}
+
+	var sigs []signing.SignatureV2
+	for _, sigJSON := range signatures {
+		decoded, err := txConfig.UnmarshalSignatureJSON(sigJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding signature: %w", err)
+		}
+		for _, sig := range decoded {
+			if sig.Sequence != sequence {
+				return nil, fmt.Errorf("signature of sequence %d, expected %d", sig.Sequence, sequence)
+			}
+		}
+		sigs = append(sigs, decoded...)
+	}
+
+	if err := setMultisigSignature(txBuilder, multiPub, sequence, sigs); err != nil {
+		return nil, err
+	}
+
+	return txConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
@@ -0,0 +1,108 @@
+package chainclient
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"go.uber.org/zap"
+)
+
+func TestMultisigTx(t *testing.T) {
+	client := NewChainClient(300000, DefaultPrefix, DefaultChainID, DefaultDenom, zap.NewNop())
+	client.AddAccount("user1", User1Mnemonic)
+	client.AddAccount("user2", User2Mnemonic)
+	if err := client.AddAccountWithOptions("user3", testMnemonic, AccountOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddOfflineMultisigAccount("multi", 2, []string{"user1", "user2", "user3"}, 0, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetMultisigSigners("multi", []string{"user1"}); err == nil {
+		t.Fatal("expected error for signers below the threshold")
+	}
+	if err := client.SetMultisigSigners("multi", []string{"user1", "user3"}); err != nil {
+		t.Fatal(err)
+	}
+	multi, _ := client.GetAccount("multi")
+
+	msg, err := client.DecodeMsgJSON([]byte(fmt.Sprintf(
+		`{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":%q,"to_address":%q,"amount":[{"denom":"stake","amount":"1"}]}`,
+		multi.Address, multi.Address,
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fee := sdk.NewCoins(sdk.NewInt64Coin(DefaultDenom, 1000))
+	txBytes, err := client.GetSignedBatchTxBytesWithGas("multi", []sdk.Msg{msg}, 200000, fee)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := client.Codec.GetTxConfig().TxDecoder()(txBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBuilder, err := client.Codec.GetTxConfig().WrapTxBuilder(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigs, err := decoded.(authsigning.SigVerifiableTx).GetSignaturesV2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiPub, err := client.multisigPubKey("multi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signBytes, err := client.multisigSignBytes(txBuilder, multiPub, multi.Number, multi.Sequence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiSig, ok := sigs[0].Data.(*signing.MultiSignatureData)
+	if !ok || len(multiSig.Signatures) != 2 {
+		t.Fatal("tx is not signed by the two signers")
+	}
+	err = multiPub.VerifyMultisignature(func(signing.SignMode) ([]byte, error) { return signBytes, nil }, multiSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the same signature is combined from the signatures of the keys made offline
+	unsigned, err := client.GenerateTx([]sdk.Msg{msg}, 200000, fee, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var partial [][]byte
+	for _, keyName := range []string{"user3", "user1"} {
+		sig, err := client.SignMultisigTxJSON(unsigned, keyName, "multi", multi.Number, multi.Sequence)
+		if err != nil {
+			t.Fatal(err)
+		}
+		partial = append(partial, sig)
+	}
+	if _, err := client.CombineMultisigTxJSON(unsigned, "multi", multi.Sequence, partial[:1]); err == nil {
+		t.Fatal("expected error for signatures below the threshold")
+	}
+	signed, err := client.CombineMultisigTxJSON(unsigned, "multi", multi.Sequence, partial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offlineBytes, err := client.EncodeTxJSON(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(offlineBytes, txBytes) {
+		t.Fatal("offline multisig tx differs from the signed tx")
+	}
+
+	if err := client.AddAccountWithOptions("other", testMnemonic, AccountOptions{HDPath: "m/44'/118'/1'/0/0"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SignMultisigTxJSON(unsigned, "other", "multi", multi.Number, multi.Sequence); err == nil {
+		t.Fatal("expected error for a key outside the multisig")
+	}
+}
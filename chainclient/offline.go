@@ -0,0 +1,113 @@
+package chainclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// DecodeMsgJSON - decode the message from its JSON with the "@type" field, e.g.
+// {"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "...", "to_address": "...", "amount": [...]}
+func (c *ChainClient) DecodeMsgJSON(msgJSON []byte) (sdk.Msg, error) {
+	var msg sdk.Msg
+	if err := c.Codec.GetMarshaler().UnmarshalInterfaceJSON(msgJSON, &msg); err != nil {
+		return nil, fmt.Errorf("error decoding message: %w", err)
+	}
+	return msg, nil
+}
+
+// GenerateTx - build the unsigned transaction with the messages and encode it as JSON
+func (c *ChainClient) GenerateTx(msgs []sdk.Msg, gasLimit uint64, fee sdk.Coins, memo string) ([]byte, error) {
+	return c.GenerateTxWithFeeGranter(msgs, gasLimit, fee, memo, "")
+}
+
+// GenerateTxWithFeeGranter - build the unsigned transaction with the fee paid by the fee allowance
+// of the granter and encode it as JSON, an empty granter leaves the fee to the signer
+func (c *ChainClient) GenerateTxWithFeeGranter(msgs []sdk.Msg, gasLimit uint64, fee sdk.Coins, memo, feeGranter string) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no messages")
+	}
+
+	txBuilder := c.Codec.GetTxConfig().NewTxBuilder()
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return nil, fmt.Errorf("set msg error: %s", err)
+	}
+	txBuilder.SetGasLimit(gasLimit)
+	txBuilder.SetFeeAmount(fee)
+	txBuilder.SetMemo(memo)
+	if feeGranter != "" {
+		granter, err := sdk.AccAddressFromBech32(feeGranter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee granter: %w", err)
+		}
+		txBuilder.SetFeeGranter(granter)
+	}
+
+	return c.Codec.GetTxConfig().TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// SignTxJSON - sign the JSON encoded transaction with the keyring key, the account number
+// and the sequence are explicit so the transaction is signed without a connection to the chain.
+// Returns the signed transaction encoded as JSON.
+func (c *ChainClient) SignTxJSON(txJSON []byte, keyName string, number, sequence uint64) ([]byte, error) {
+	txConfig := c.Codec.GetTxConfig()
+	txBuilder, err := c.wrapTxJSON(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := tx.Factory{}.
+		WithKeybase(c.keyring).
+		WithChainID(c.chainID).
+		WithAccountNumber(number).
+		WithSequence(sequence).
+		WithTxConfig(txConfig)
+
+	if err := tx.Sign(context.Background(), factory, keyName, txBuilder, true); err != nil {
+		return nil, fmt.Errorf("sign tx error: %s", err)
+	}
+
+	return txConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// wrapTxJSON - decode the JSON encoded transaction into the builder
+func (c *ChainClient) wrapTxJSON(txJSON []byte) (client.TxBuilder, error) {
+	txConfig := c.Codec.GetTxConfig()
+	decoded, err := txConfig.TxJSONDecoder()(txJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding tx: %w", err)
+	}
+
+	txBuilder, err := txConfig.WrapTxBuilder(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping tx: %w", err)
+	}
+	return txBuilder, nil
+}
+
+// EncodeTxJSON - encode the JSON encoded transaction to the bytes broadcast to the chain
+func (c *ChainClient) EncodeTxJSON(txJSON []byte) ([]byte, error) {
+	txConfig := c.Codec.GetTxConfig()
+	decoded, err := txConfig.TxJSONDecoder()(txJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding tx: %w", err)
+	}
+
+	sigTx, ok := decoded.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil, fmt.Errorf("tx does not have signatures")
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return nil, fmt.Errorf("error reading signatures: %w", err)
+	}
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("tx is not signed")
+	}
+
+	return txConfig.TxEncoder()(decoded)
+}
@@ -0,0 +1,52 @@
+package chainclient
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+func TestOfflineSigning(t *testing.T) {
+	client := NewChainClient(300000, DefaultPrefix, DefaultChainID, DefaultDenom, zap.NewNop())
+	client.AddOfflineAccount("user1", User1Mnemonic, 3, 1)
+	client.AddOfflineAccount("user2", User2Mnemonic, 0, 2)
+	acc1, _ := client.GetAccount("user1")
+	acc2, _ := client.GetAccount("user2")
+
+	msg, err := client.DecodeMsgJSON([]byte(fmt.Sprintf(
+		`{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":%q,"to_address":%q,"amount":[{"denom":"stake","amount":"1000"}]}`,
+		acc1.Address, acc2.Address,
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fee := sdk.NewCoins(sdk.NewInt64Coin(DefaultDenom, 100000))
+	unsigned, err := client.GenerateTx([]sdk.Msg{msg}, 200000, fee, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.EncodeTxJSON(unsigned); err == nil {
+		t.Fatal("expected error for unsigned tx")
+	}
+
+	signed, err := client.SignTxJSON(unsigned, "user1", acc1.Number, acc1.Sequence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBytes, err := client.EncodeTxJSON(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := client.GetSignedTxBytesWithGas("user1", msg, 200000, fee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(txBytes, want) {
+		t.Fatal("offline signed tx differs from the tx signed by the client")
+	}
+}
@@ -0,0 +1,78 @@
+package chainclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// placeholderRe matches placeholders like {{ kernel.address }}, {{ vault.code_id }},
+// {{ time.unix_nano + 24h }} or {{ time.unix_nano + 24h | string }}
+var placeholderRe = regexp.MustCompile(
+	`\{\{\s*([A-Za-z0-9_\-]+)\.([A-Za-z0-9_\-]+)(?:\s*\+\s*([0-9a-z.]+))?(?:\s*\|\s*(string|number))?\s*\}\}`,
+)
+
+// Placeholder - parsed {{ namespace.key + offset | type }} placeholder,
+// Offset and Type are empty if they are not set
+type Placeholder struct {
+	Namespace string
+	Key       string
+	Offset    string
+	Type      string
+}
+
+// String - the placeholder without the braces
+func (p Placeholder) String() string {
+	return p.Namespace + "." + p.Key
+}
+
+// parsePlaceholder - the placeholder of the submatches of placeholderRe
+func parsePlaceholder(ref []string) Placeholder {
+	return Placeholder{Namespace: ref[1], Key: ref[2], Offset: ref[3], Type: ref[4]}
+}
+
+// ReplacePlaceholders - replace every placeholder in s by the value returned by lookup,
+// the first lookup error is returned
+func ReplacePlaceholders(s string, lookup func(p Placeholder) (string, error)) (string, error) {
+	var resolveErr error
+	resolved := placeholderRe.ReplaceAllStringFunc(s, func(match string) string {
+		value, err := lookup(parsePlaceholder(placeholderRe.FindStringSubmatch(match)))
+		if err != nil {
+			if resolveErr == nil {
+				resolveErr = err
+			}
+			return match
+		}
+		return value
+	})
+	return resolved, resolveErr
+}
+
+// UnmarshalFile - decode the YAML or JSON file into v by the file extension,
+// unknown fields are an error
+func UnmarshalFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if data, err = yaml.YAMLToJSON(data); err != nil {
+			return err
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package chainclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	authv1beta1 "cosmossdk.io/api/cosmos/auth/v1beta1"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/proto"
+)
+
+// executeQuery performs the query and unmarshals the response, a failed query is fatal
+func (s *ChainService) executeQuery(queryPath string, req interface{}, res interface{}) error {
+	if err := s.query(queryPath, req, res); err != nil {
+		s.log.Fatal("ABCIQuery failed", zap.String("path", queryPath), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// query performs the query and unmarshals the response
+func (s *ChainService) query(queryPath string, req interface{}, res interface{}) error {
+	queryArgs, err := encoding.GetCodec(proto.Name).Marshal(req)
+	if err != nil {
+		return fmt.Errorf("error marshaling request: %w", err)
+	}
+
+	resABCIQuery, err := s.c.ABCIQuery(context.Background(), queryPath, queryArgs)
+	if err != nil {
+		return err
+	}
+
+	if resABCIQuery.Response.IsErr() {
+		return errors.New(resABCIQuery.Response.Log)
+	}
+
+	if err := encoding.GetCodec(proto.Name).Unmarshal(resABCIQuery.Response.Value, res); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// Query - perform the ABCI query of the gRPC method path, e.g. "/cosmos.bank.v1beta1.Query/Balance",
+// and unmarshal the response
+func (s *ChainService) Query(queryPath string, req interface{}, res interface{}) error {
+	return s.query(queryPath, req, res)
+}
+
+// GetBalances queries the balances of an address
+func (s *ChainService) GetBalances(address string) (sdk.Coins, error) {
+	var (
+		queryPath = "/cosmos.bank.v1beta1.Query/AllBalances"
+		req       = &bank.QueryAllBalancesRequest{
+			Address: address,
+		}
+		res = &bank.QueryAllBalancesResponse{}
+	)
+
+	err := s.executeQuery(queryPath, req, res)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Balance query success",
+		zap.String("address", address),
+		zap.String("balances", res.Balances.String()),
+	)
+	return res.Balances, nil
+}
+
+// QuerySmartContractState queries the contract state with the json query
+// and returns the raw json response
+func (s *ChainService) QuerySmartContractState(contractAddress string, query []byte) ([]byte, error) {
+	var (
+		queryPath = "/cosmwasm.wasm.v1.Query/SmartContractState"
+		req       = &wasmtypes.QuerySmartContractStateRequest{
+			Address:   contractAddress,
+			QueryData: query,
+		}
+		res = &wasmtypes.QuerySmartContractStateResponse{}
+	)
+
+	err := s.executeQuery(queryPath, req, res)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Smart contract state query success",
+		zap.String("address", contractAddress),
+		zap.ByteString("data", res.Data),
+	)
+	return res.Data, nil
+}
+
+// GetAccountInfo queries the account number and sequence of an address
+func (s *ChainService) GetAccountInfo(address string) (*authv1beta1.BaseAccount, error) {
+	var (
+		queryPath = "/cosmos.auth.v1beta1.Query/AccountInfo"
+		req       = &authv1beta1.QueryAccountInfoRequest{
+			Address: address,
+		}
+		res = &authv1beta1.QueryAccountInfoResponse{}
+	)
+
+	if err := s.query(queryPath, req, res); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("account %s is not found on chain %s, it must be funded before sending transactions: %w",
+				address, s.client.GetChainID(), err)
+		}
+		return nil, err
+	}
+	if res.Info == nil {
+		return nil, fmt.Errorf("account %s has no info", address)
+	}
+
+	return res.Info, nil
+}
+
+// LoadAccount loads the account number and sequence of the signer from the chain
+// if they are not loaded yet
+func (s *ChainService) LoadAccount(signerName string) error {
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		return fmt.Errorf("account %s not found", signerName)
+	}
+	if acc.Loaded {
+		return nil
+	}
+
+	info, err := s.GetAccountInfo(acc.Address)
+	if err != nil {
+		return fmt.Errorf("error loading account %s: %w", signerName, err)
+	}
+
+	s.log.Info("Account loaded",
+		zap.String("signerName", signerName),
+		zap.String("address", acc.Address),
+		zap.Uint64("accountNumber", info.AccountNumber),
+		zap.Uint64("sequence", info.Sequence),
+	)
+	return s.client.loadAccount(signerName, info.AccountNumber, info.Sequence)
+}
+
+// UpdateAccountSequence updates the account sequence of an address
+func (s *ChainService) UpdateAccountSequence(signerName string) error {
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		s.log.Fatal("account not found", zap.String("signerName", signerName))
+		return errors.New("account not found")
+	}
+
+	info, err := s.GetAccountInfo(acc.Address)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.loadAccount(signerName, info.AccountNumber, info.Sequence); err != nil {
+		return err
+	}
+	return s.client.SetSequence(signerName, info.Sequence)
+}
+
+// GetCode queries the code info and the wasm byte code of the code id
+func (s *ChainService) GetCode(codeID uint64) (*wasmtypes.QueryCodeResponse, error) {
+	res := &wasmtypes.QueryCodeResponse{}
+	if err := s.query("/cosmwasm.wasm.v1.Query/Code", &wasmtypes.QueryCodeRequest{CodeId: codeID}, res); err != nil {
+		return nil, err
+	}
+	if res.CodeInfoResponse == nil {
+		return nil, fmt.Errorf("code %d not found", codeID)
+	}
+
+	return res, nil
+}
+
+// QuerySmartContract queries the contract with the json query and returns the raw json response,
+// unlike QuerySmartContractState a failed query is returned as an error
+func (s *ChainService) QuerySmartContract(address string, query []byte) ([]byte, error) {
+	res := &wasmtypes.QuerySmartContractStateResponse{}
+	req := &wasmtypes.QuerySmartContractStateRequest{Address: address, QueryData: query}
+	if err := s.query("/cosmwasm.wasm.v1.Query/SmartContractState", req, res); err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// GetContractInfo queries the code id, creator, admin and label of the contract
+func (s *ChainService) GetContractInfo(address string) (*wasmtypes.ContractInfo, error) {
+	res := &wasmtypes.QueryContractInfoResponse{}
+	if err := s.query("/cosmwasm.wasm.v1.Query/ContractInfo", &wasmtypes.QueryContractInfoRequest{Address: address}, res); err != nil {
+		return nil, err
+	}
+
+	return &res.ContractInfo, nil
+}
+
+// QueryRawContractState queries the value of the key of the contract store, nil if the key is not set
+func (s *ChainService) QueryRawContractState(address string, key []byte) ([]byte, error) {
+	res := &wasmtypes.QueryRawContractStateResponse{}
+	req := &wasmtypes.QueryRawContractStateRequest{Address: address, QueryData: key}
+	if err := s.query("/cosmwasm.wasm.v1.Query/RawContractState", req, res); err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// GetAllContractState queries a page of the keys and values of the contract store
+func (s *ChainService) GetAllContractState(address string, page *query.PageRequest) (*wasmtypes.QueryAllContractStateResponse, error) {
+	res := &wasmtypes.QueryAllContractStateResponse{}
+	req := &wasmtypes.QueryAllContractStateRequest{Address: address, Pagination: page}
+	if err := s.query("/cosmwasm.wasm.v1.Query/AllContractState", req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetContractHistory queries a page of the code history of the contract:
+// the instantiation and the migrations with their messages
+func (s *ChainService) GetContractHistory(address string, page *query.PageRequest) (*wasmtypes.QueryContractHistoryResponse, error) {
+	res := &wasmtypes.QueryContractHistoryResponse{}
+	req := &wasmtypes.QueryContractHistoryRequest{Address: address, Pagination: page}
+	if err := s.query("/cosmwasm.wasm.v1.Query/ContractHistory", req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,190 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v8/modules/core/23-commitment/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+)
+
+// validatorsPerPage - max page size of the validators rpc
+const validatorsPerPage = 100
+
+// Chain - chain connected by the relayer, the relayer account signs the IBC messages
+// and the ticks which build the blocks of the proofs
+type Chain struct {
+	service  *chainclient.ChainService
+	signer   string
+	revision uint64
+	log      chainclient.Logger
+}
+
+// NewChain - create a chain signing the relayer messages with the account of the chain client
+func NewChain(service *chainclient.ChainService, signer string, log chainclient.Logger) (*Chain, error) {
+	if _, ok := service.Client().GetAccount(signer); !ok {
+		return nil, fmt.Errorf("account %s not found", signer)
+	}
+
+	return &Chain{
+		service:  service,
+		signer:   signer,
+		revision: clienttypes.ParseChainID(service.Client().GetChainID()),
+		log:      log,
+	}, nil
+}
+
+// ChainID - the chain ID
+func (c *Chain) ChainID() string {
+	return c.service.Client().GetChainID()
+}
+
+// Service - the chain service of the chain
+func (c *Chain) Service() *chainclient.ChainService {
+	return c.service
+}
+
+// SignerAddress - the address of the relayer account
+func (c *Chain) SignerAddress() string {
+	acc, _ := c.service.Client().GetAccount(c.signer)
+	return acc.Address
+}
+
+// height - the IBC height of the block
+func (c *Chain) height(height int64) clienttypes.Height {
+	return clienttypes.NewHeight(c.revision, uint64(height))
+}
+
+// send - sign the messages with the relayer account and wait for the transaction
+func (c *Chain) send(msgs ...sdk.Msg) (*coretypes.ResultTx, error) {
+	res, err := c.service.SendMsgs(c.signer, msgs, chainclient.AutoGas)
+	if err != nil {
+		return nil, fmt.Errorf("error sending %s on %s: %w", sdk.MsgTypeURL(msgs[len(msgs)-1]), c.ChainID(), err)
+	}
+	return res, nil
+}
+
+// tick - commit a block with a self transfer of the relayer account, returns its height.
+// LandslideVM builds blocks only for transactions, the state of the block H is proven
+// by the app hash of the header H+1, so a block is committed after the state to prove.
+func (c *Chain) tick() (int64, error) {
+	address := c.SignerAddress()
+	msg := banktypes.NewMsgSend(
+		sdk.MustAccAddressFromBech32(address),
+		sdk.MustAccAddressFromBech32(address),
+		sdk.NewCoins(sdk.NewInt64Coin(c.service.Client().GetDenom(), 1)),
+	)
+	res, err := c.send(msg)
+	if err != nil {
+		return 0, err
+	}
+	return res.Height, nil
+}
+
+// header - the signed header and the validator set of the block
+func (c *Chain) header(height int64) (*cmtproto.SignedHeader, *cmtproto.ValidatorSet, error) {
+	commit, err := c.service.RPC().Commit(context.Background(), &height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting commit %d of %s: %w", height, c.ChainID(), err)
+	}
+	if commit.Header == nil || commit.Commit == nil {
+		return nil, nil, fmt.Errorf("commit %d of %s has no header or commit", height, c.ChainID())
+	}
+
+	validators, err := c.validatorSet(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	return commit.SignedHeader.ToProto(), validators, nil
+}
+
+// validatorSet - the validator set of the block
+func (c *Chain) validatorSet(height int64) (*cmtproto.ValidatorSet, error) {
+	var (
+		validators []*cmttypes.Validator
+		perPage    = validatorsPerPage
+	)
+	for page := 1; ; page++ {
+		res, err := c.service.RPC().Validators(context.Background(), &height, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("error getting validators %d of %s: %w", height, c.ChainID(), err)
+		}
+		validators = append(validators, res.Validators...)
+		if len(validators) >= res.Total || len(res.Validators) == 0 {
+			break
+		}
+	}
+
+	valSet, err := cmttypes.ValidatorSetFromExistingValidators(validators)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validator set %d of %s: %w", height, c.ChainID(), err)
+	}
+	return valSet.ToProto()
+}
+
+// unbondingTime - the unbonding time of the staking module of the chain
+func (c *Chain) unbondingTime() (time.Duration, error) {
+	res := &stakingtypes.QueryParamsResponse{}
+	if err := c.service.Query("/cosmos.staking.v1beta1.Query/Params", &stakingtypes.QueryParamsRequest{}, res); err != nil {
+		return 0, fmt.Errorf("error querying staking params of %s: %w", c.ChainID(), err)
+	}
+	return res.Params.UnbondingTime, nil
+}
+
+// queryProof - query the value of the IBC store key with its merkle proof at the height,
+// returns the value, the proof and the height of the header proving it
+func (c *Chain) queryProof(key []byte, height int64) ([]byte, []byte, clienttypes.Height, error) {
+	res, err := c.service.RPC().ABCIQueryWithOptions(
+		context.Background(),
+		fmt.Sprintf("store/%s/key", ibcexported.StoreKey),
+		key,
+		rpcclient.ABCIQueryOptions{Height: height, Prove: true},
+	)
+	if err != nil {
+		return nil, nil, clienttypes.Height{}, fmt.Errorf("error querying %s of %s: %w", key, c.ChainID(), err)
+	}
+	if res.Response.IsErr() {
+		return nil, nil, clienttypes.Height{}, fmt.Errorf("error querying %s of %s: %s", key, c.ChainID(), res.Response.Log)
+	}
+	if len(res.Response.Value) == 0 {
+		return nil, nil, clienttypes.Height{}, fmt.Errorf("%s not found on %s at %d", key, c.ChainID(), height)
+	}
+
+	merkleProof, err := commitmenttypes.ConvertProofs(res.Response.ProofOps)
+	if err != nil {
+		return nil, nil, clienttypes.Height{}, fmt.Errorf("invalid proof of %s of %s: %w", key, c.ChainID(), err)
+	}
+	proof, err := c.service.Client().Codec.GetMarshaler().Marshal(&merkleProof)
+	if err != nil {
+		return nil, nil, clienttypes.Height{}, err
+	}
+
+	return res.Response.Value, proof, c.height(res.Response.Height + 1), nil
+}
+
+// EventAttribute - the value of the attribute of the first event of the type
+func EventAttribute(events []abci.Event, eventType, key string) (string, error) {
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == key {
+				return attr.Value, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("attribute %s of event %s not found", key, eventType)
+}
@@ -0,0 +1,169 @@
+package relayer
+
+import (
+	"fmt"
+	"time"
+
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v8/modules/core/23-commitment/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+	"go.uber.org/zap"
+)
+
+// maxClockDrift - max difference of the header time and the time of the block verifying it
+const maxClockDrift = 10 * time.Second
+
+// upgradePath - store path of the upgraded client states, the same as of the SDK chains
+var upgradePath = []string{upgradetypes.StoreKey, upgradetypes.KeyUpgradedIBCState}
+
+// merklePrefix - prefix of the IBC store of the chains
+var merklePrefix = commitmenttypes.NewMerklePrefix([]byte(ibcexported.StoreKey))
+
+// Endpoint - one end of a path: the chain, its light client of the counterparty chain,
+// the connection and the channel
+type Endpoint struct {
+	Chain        *Chain
+	Counterparty *Endpoint
+
+	ClientID     string
+	ConnectionID string
+	PortID       string
+	ChannelID    string
+}
+
+// CreateClient - create the 07-tendermint client of the counterparty chain at its latest block.
+// The unbonding period of the client is the one of the counterparty staking module,
+// the counterparty checks it when it validates the client in the connection handshake.
+func (e *Endpoint) CreateClient() error {
+	cp := e.Counterparty.Chain
+	height, err := cp.tick()
+	if err != nil {
+		return err
+	}
+	signedHeader, _, err := cp.header(height)
+	if err != nil {
+		return err
+	}
+	unbondingTime, err := cp.unbondingTime()
+	if err != nil {
+		return err
+	}
+
+	clientState := ibctm.NewClientState(
+		cp.ChainID(),
+		ibctm.DefaultTrustLevel,
+		unbondingTime*2/3,
+		unbondingTime,
+		maxClockDrift,
+		cp.height(height),
+		commitmenttypes.GetSDKSpecs(),
+		upgradePath,
+	)
+	consensusState := ibctm.NewConsensusState(
+		signedHeader.Header.Time,
+		commitmenttypes.NewMerkleRoot(signedHeader.Header.AppHash),
+		signedHeader.Header.NextValidatorsHash,
+	)
+	msg, err := clienttypes.NewMsgCreateClient(clientState, consensusState, e.Chain.SignerAddress())
+	if err != nil {
+		return err
+	}
+
+	res, err := e.Chain.send(msg)
+	if err != nil {
+		return err
+	}
+	e.ClientID, err = EventAttribute(res.TxResult.Events, clienttypes.EventTypeCreateClient, clienttypes.AttributeKeyClientID)
+	if err != nil {
+		return err
+	}
+
+	e.Chain.log.Info("Client created",
+		zap.String("chainID", e.Chain.ChainID()),
+		zap.String("clientID", e.ClientID),
+		zap.String("counterpartyChainID", cp.ChainID()),
+		zap.Int64("height", height),
+	)
+	return nil
+}
+
+// UpdateClient - update the client to the latest block of the counterparty chain
+func (e *Endpoint) UpdateClient() error {
+	height, err := e.Counterparty.Chain.tick()
+	if err != nil {
+		return err
+	}
+	_, err = e.sendWithUpdate(height)
+	return err
+}
+
+// ClientState - the state of the client of the counterparty chain
+func (e *Endpoint) ClientState() (*ibctm.ClientState, error) {
+	res := &clienttypes.QueryClientStateResponse{}
+	err := e.Chain.service.Query("/ibc.core.client.v1.Query/ClientState", &clienttypes.QueryClientStateRequest{ClientId: e.ClientID}, res)
+	if err != nil {
+		return nil, fmt.Errorf("error querying client %s of %s: %w", e.ClientID, e.Chain.ChainID(), err)
+	}
+
+	var clientState ibcexported.ClientState
+	if err := e.Chain.service.Client().Codec.GetInterfaceRegistry().UnpackAny(res.ClientState, &clientState); err != nil {
+		return nil, fmt.Errorf("error decoding client %s of %s: %w", e.ClientID, e.Chain.ChainID(), err)
+	}
+	tmClientState, ok := clientState.(*ibctm.ClientState)
+	if !ok {
+		return nil, fmt.Errorf("client %s of %s is not a tendermint client", e.ClientID, e.Chain.ChainID())
+	}
+	return tmClientState, nil
+}
+
+// updateClientMsg - the message updating the client to the header of the counterparty block,
+// nil if the client is already at the block
+func (e *Endpoint) updateClientMsg(height int64) (sdk.Msg, error) {
+	clientState, err := e.ClientState()
+	if err != nil {
+		return nil, err
+	}
+	trustedHeight := clientState.LatestHeight
+	if trustedHeight.RevisionHeight >= uint64(height) {
+		return nil, nil
+	}
+
+	cp := e.Counterparty.Chain
+	signedHeader, validators, err := cp.header(height)
+	if err != nil {
+		return nil, err
+	}
+	// the trusted consensus state has the hash of the next validators of the trusted block
+	trustedValidators, err := cp.validatorSet(int64(trustedHeight.RevisionHeight) + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &ibctm.Header{
+		SignedHeader:      signedHeader,
+		ValidatorSet:      validators,
+		TrustedHeight:     trustedHeight,
+		TrustedValidators: trustedValidators,
+	}
+	return clienttypes.NewMsgUpdateClient(e.ClientID, header, e.Chain.SignerAddress())
+}
+
+// sendWithUpdate - send the messages in one transaction with the update of the client
+// to the counterparty block of the proofs of the messages
+func (e *Endpoint) sendWithUpdate(height int64, msgs ...sdk.Msg) (*coretypes.ResultTx, error) {
+	update, err := e.updateClientMsg(height)
+	if err != nil {
+		return nil, err
+	}
+	if update != nil {
+		msgs = append([]sdk.Msg{update}, msgs...)
+	}
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+	return e.Chain.send(msgs...)
+}
@@ -0,0 +1,149 @@
+package relayer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	"go.uber.org/zap"
+)
+
+// RelayPackets - relay the packets sent by the transaction on the chain of the endpoint
+// to the counterparty and their acknowledgements back, returns the acknowledgements
+func (e *Endpoint) RelayPackets(res *coretypes.ResultTx) ([][]byte, error) {
+	packets, err := ParsePackets(res.TxResult.Events)
+	if err != nil {
+		return nil, err
+	}
+	if len(packets) == 0 {
+		return nil, fmt.Errorf("transaction %s sent no packets", res.Hash)
+	}
+
+	acks := make([][]byte, 0, len(packets))
+	for _, packet := range packets {
+		ack, err := e.relayPacket(packet)
+		if err != nil {
+			return nil, err
+		}
+		acks = append(acks, ack)
+	}
+	return acks, nil
+}
+
+// relayPacket - receive the packet on the counterparty and acknowledge it on the endpoint
+func (e *Endpoint) relayPacket(packet channeltypes.Packet) ([]byte, error) {
+	cp := e.Counterparty
+
+	height, err := e.Chain.tick()
+	if err != nil {
+		return nil, err
+	}
+	_, proofCommitment, proofHeight, err := e.Chain.queryProof(
+		host.PacketCommitmentKey(packet.SourcePort, packet.SourceChannel, packet.Sequence), height-1,
+	)
+	if err != nil {
+		return nil, err
+	}
+	msgRecv := channeltypes.NewMsgRecvPacket(packet, proofCommitment, proofHeight, cp.Chain.SignerAddress())
+	res, err := cp.sendWithUpdate(height, msgRecv)
+	if err != nil {
+		return nil, err
+	}
+	ack, err := ParseAck(res.TxResult.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err = cp.Chain.tick()
+	if err != nil {
+		return nil, err
+	}
+	_, proofAcked, proofHeight, err := cp.Chain.queryProof(
+		host.PacketAcknowledgementKey(packet.DestinationPort, packet.DestinationChannel, packet.Sequence), height-1,
+	)
+	if err != nil {
+		return nil, err
+	}
+	msgAck := channeltypes.NewMsgAcknowledgement(packet, ack, proofAcked, proofHeight, e.Chain.SignerAddress())
+	if _, err := e.sendWithUpdate(height, msgAck); err != nil {
+		return nil, err
+	}
+
+	e.Chain.log.Info("Packet relayed",
+		zap.String("chainID", e.Chain.ChainID()),
+		zap.String("port", packet.SourcePort),
+		zap.String("channel", packet.SourceChannel),
+		zap.Uint64("sequence", packet.Sequence),
+		zap.ByteString("ack", ack),
+	)
+	return ack, nil
+}
+
+// ParsePackets - the packets of the send_packet events
+func ParsePackets(events []abci.Event) ([]channeltypes.Packet, error) {
+	var packets []channeltypes.Packet
+	for _, event := range events {
+		if event.Type != channeltypes.EventTypeSendPacket {
+			continue
+		}
+
+		var (
+			packet channeltypes.Packet
+			err    error
+		)
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case channeltypes.AttributeKeyDataHex:
+				packet.Data, err = hex.DecodeString(attr.Value)
+			case channeltypes.AttributeKeySequence:
+				packet.Sequence, err = strconv.ParseUint(attr.Value, 10, 64)
+			case channeltypes.AttributeKeySrcPort:
+				packet.SourcePort = attr.Value
+			case channeltypes.AttributeKeySrcChannel:
+				packet.SourceChannel = attr.Value
+			case channeltypes.AttributeKeyDstPort:
+				packet.DestinationPort = attr.Value
+			case channeltypes.AttributeKeyDstChannel:
+				packet.DestinationChannel = attr.Value
+			case channeltypes.AttributeKeyTimeoutHeight:
+				packet.TimeoutHeight, err = clienttypes.ParseHeight(attr.Value)
+			case channeltypes.AttributeKeyTimeoutTimestamp:
+				packet.TimeoutTimestamp, err = strconv.ParseUint(attr.Value, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid packet attribute %s: %w", attr.Key, err)
+			}
+		}
+		if err := packet.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid packet: %w", err)
+		}
+		packets = append(packets, packet)
+	}
+	return packets, nil
+}
+
+// ParseAck - the acknowledgement of the write_acknowledgement event
+func ParseAck(events []abci.Event) ([]byte, error) {
+	value, err := EventAttribute(events, channeltypes.EventTypeWriteAck, channeltypes.AttributeKeyAckHex)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(value)
+}
+
+// CheckAck - check the acknowledgement is a success, returns the result of the packet
+func CheckAck(ack []byte) ([]byte, error) {
+	var acknowledgement channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(ack, &acknowledgement); err != nil {
+		return nil, fmt.Errorf("error decoding acknowledgement %s: %w", ack, err)
+	}
+	if !acknowledgement.Success() {
+		return nil, fmt.Errorf("packet failed: %s", acknowledgement.GetError())
+	}
+	return acknowledgement.GetResult(), nil
+}
@@ -0,0 +1,61 @@
+package relayer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+func TestParsePackets(t *testing.T) {
+	data := []byte(`{"amount":"1000","denom":"stake"}`)
+	ack := channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()
+	events := []abci.Event{
+		{Type: "message", Attributes: []abci.EventAttribute{{Key: "module", Value: "transfer"}}},
+		{Type: channeltypes.EventTypeSendPacket, Attributes: []abci.EventAttribute{
+			{Key: channeltypes.AttributeKeyDataHex, Value: hex.EncodeToString(data)},
+			{Key: channeltypes.AttributeKeyTimeoutHeight, Value: "0-0"},
+			{Key: channeltypes.AttributeKeyTimeoutTimestamp, Value: "1700000000000000000"},
+			{Key: channeltypes.AttributeKeySequence, Value: "3"},
+			{Key: channeltypes.AttributeKeySrcPort, Value: "transfer"},
+			{Key: channeltypes.AttributeKeySrcChannel, Value: "channel-0"},
+			{Key: channeltypes.AttributeKeyDstPort, Value: "transfer"},
+			{Key: channeltypes.AttributeKeyDstChannel, Value: "channel-1"},
+		}},
+		{Type: channeltypes.EventTypeWriteAck, Attributes: []abci.EventAttribute{
+			{Key: channeltypes.AttributeKeyAckHex, Value: hex.EncodeToString(ack)},
+		}},
+	}
+
+	packets, err := ParsePackets(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 packet, got %d", len(packets))
+	}
+	packet := packets[0]
+	if packet.Sequence != 3 || packet.SourceChannel != "channel-0" || packet.DestinationChannel != "channel-1" ||
+		packet.TimeoutTimestamp != 1700000000000000000 || !packet.TimeoutHeight.IsZero() || !bytes.Equal(packet.Data, data) {
+		t.Fatalf("unexpected packet %+v", packet)
+	}
+
+	parsed, err := ParseAck(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := CheckAck(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, []byte{1}) {
+		t.Fatalf("unexpected ack result %x", result)
+	}
+
+	errorAck := channeltypes.NewErrorAcknowledgement(channeltypes.ErrInvalidPacket).Acknowledgement()
+	if _, err := CheckAck(errorAck); err == nil {
+		t.Fatal("expected error acknowledgement to fail")
+	}
+}
@@ -0,0 +1,278 @@
+package relayer
+
+import (
+	"fmt"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+	"go.uber.org/zap"
+)
+
+// Path - two endpoints connected by the relayer, the endpoint A starts the handshakes
+type Path struct {
+	EndpointA *Endpoint
+	EndpointB *Endpoint
+}
+
+// NewPath - create the path between the chains
+func NewPath(chainA, chainB *Chain) *Path {
+	endpointA := &Endpoint{Chain: chainA}
+	endpointB := &Endpoint{Chain: chainB}
+	endpointA.Counterparty = endpointB
+	endpointB.Counterparty = endpointA
+
+	return &Path{
+		EndpointA: endpointA,
+		EndpointB: endpointB,
+	}
+}
+
+// ConnectionPath - a path on the clients and the connection of the path, without a channel,
+// to open another channel on the connection
+func (p *Path) ConnectionPath() *Path {
+	path := NewPath(p.EndpointA.Chain, p.EndpointB.Chain)
+	path.EndpointA.ClientID, path.EndpointA.ConnectionID = p.EndpointA.ClientID, p.EndpointA.ConnectionID
+	path.EndpointB.ClientID, path.EndpointB.ConnectionID = p.EndpointB.ClientID, p.EndpointB.ConnectionID
+	return path
+}
+
+// Setup - create the clients and open the connection of the path
+func (p *Path) Setup() error {
+	if err := p.EndpointA.CreateClient(); err != nil {
+		return err
+	}
+	if err := p.EndpointB.CreateClient(); err != nil {
+		return err
+	}
+	return p.CreateConnection()
+}
+
+// CreateConnection - open the connection between the clients of the endpoints
+func (p *Path) CreateConnection() error {
+	a, b := p.EndpointA, p.EndpointB
+
+	msgInit := connectiontypes.NewMsgConnectionOpenInit(
+		a.ClientID, b.ClientID, merklePrefix, connectiontypes.DefaultIBCVersion, 0, a.Chain.SignerAddress(),
+	)
+	res, err := a.Chain.send(msgInit)
+	if err != nil {
+		return err
+	}
+	a.ConnectionID, err = EventAttribute(res.TxResult.Events, connectiontypes.EventTypeConnectionOpenInit, connectiontypes.AttributeKeyConnectionID)
+	if err != nil {
+		return err
+	}
+
+	// OpenTry on B with the proofs of the connection and the client of A
+	height, proofs, err := a.connectionProofs()
+	if err != nil {
+		return err
+	}
+	msgTry := connectiontypes.NewMsgConnectionOpenTry(
+		b.ClientID, a.ConnectionID, a.ClientID, proofs.clientState, merklePrefix,
+		proofs.connection.Versions, proofs.connection.DelayPeriod,
+		proofs.connectionProof, proofs.clientProof, proofs.consensusProof,
+		proofs.proofHeight, proofs.consensusHeight, b.Chain.SignerAddress(),
+	)
+	res, err = b.sendWithUpdate(height, msgTry)
+	if err != nil {
+		return err
+	}
+	b.ConnectionID, err = EventAttribute(res.TxResult.Events, connectiontypes.EventTypeConnectionOpenTry, connectiontypes.AttributeKeyConnectionID)
+	if err != nil {
+		return err
+	}
+
+	// OpenAck on A with the proofs of the connection and the client of B
+	height, proofs, err = b.connectionProofs()
+	if err != nil {
+		return err
+	}
+	msgAck := connectiontypes.NewMsgConnectionOpenAck(
+		a.ConnectionID, b.ConnectionID, proofs.clientState,
+		proofs.connectionProof, proofs.clientProof, proofs.consensusProof,
+		proofs.proofHeight, proofs.consensusHeight, proofs.connection.Versions[0], a.Chain.SignerAddress(),
+	)
+	if _, err := a.sendWithUpdate(height, msgAck); err != nil {
+		return err
+	}
+
+	// OpenConfirm on B with the proof of the open connection of A
+	height, err = a.Chain.tick()
+	if err != nil {
+		return err
+	}
+	_, proofAck, proofHeight, err := a.Chain.queryProof(host.ConnectionKey(a.ConnectionID), height-1)
+	if err != nil {
+		return err
+	}
+	msgConfirm := connectiontypes.NewMsgConnectionOpenConfirm(b.ConnectionID, proofAck, proofHeight, b.Chain.SignerAddress())
+	if _, err := b.sendWithUpdate(height, msgConfirm); err != nil {
+		return err
+	}
+
+	a.Chain.log.Info("Connection open",
+		zap.String("chainA", a.Chain.ChainID()),
+		zap.String("connectionA", a.ConnectionID),
+		zap.String("chainB", b.Chain.ChainID()),
+		zap.String("connectionB", b.ConnectionID),
+	)
+	return nil
+}
+
+// CreateChannel - open the channel between the ports of the endpoints
+func (p *Path) CreateChannel(portA, portB, version string, order channeltypes.Order) error {
+	a, b := p.EndpointA, p.EndpointB
+	a.PortID = portA
+	b.PortID = portB
+
+	msgInit := channeltypes.NewMsgChannelOpenInit(a.PortID, version, order, []string{a.ConnectionID}, b.PortID, a.Chain.SignerAddress())
+	res, err := a.Chain.send(msgInit)
+	if err != nil {
+		return err
+	}
+	a.ChannelID, err = EventAttribute(res.TxResult.Events, channeltypes.EventTypeChannelOpenInit, channeltypes.AttributeKeyChannelID)
+	if err != nil {
+		return err
+	}
+
+	return p.CompleteChannelHandshake()
+}
+
+// CompleteChannelHandshake - open the channel initialized on the endpoint A, e.g. by an application
+// like interchain accounts, the port and the channel of A and the port of B must be set
+func (p *Path) CompleteChannelHandshake() error {
+	a, b := p.EndpointA, p.EndpointB
+
+	// OpenTry on B with the proof of the channel of A
+	height, channelA, proofInit, err := a.channelProof()
+	if err != nil {
+		return err
+	}
+	msgTry := channeltypes.NewMsgChannelOpenTry(
+		b.PortID, channelA.Version, channelA.Ordering, []string{b.ConnectionID},
+		a.PortID, a.ChannelID, channelA.Version, proofInit, a.Chain.height(height), b.Chain.SignerAddress(),
+	)
+	res, err := b.sendWithUpdate(height, msgTry)
+	if err != nil {
+		return err
+	}
+	b.ChannelID, err = EventAttribute(res.TxResult.Events, channeltypes.EventTypeChannelOpenTry, channeltypes.AttributeKeyChannelID)
+	if err != nil {
+		return err
+	}
+
+	// OpenAck on A with the proof of the channel of B and the version chosen by B
+	height, channelB, proofTry, err := b.channelProof()
+	if err != nil {
+		return err
+	}
+	msgAck := channeltypes.NewMsgChannelOpenAck(
+		a.PortID, a.ChannelID, b.ChannelID, channelB.Version, proofTry, b.Chain.height(height), a.Chain.SignerAddress(),
+	)
+	if _, err := a.sendWithUpdate(height, msgAck); err != nil {
+		return err
+	}
+
+	// OpenConfirm on B with the proof of the open channel of A
+	height, _, proofAck, err := a.channelProof()
+	if err != nil {
+		return err
+	}
+	msgConfirm := channeltypes.NewMsgChannelOpenConfirm(b.PortID, b.ChannelID, proofAck, a.Chain.height(height), b.Chain.SignerAddress())
+	if _, err := b.sendWithUpdate(height, msgConfirm); err != nil {
+		return err
+	}
+
+	a.Chain.log.Info("Channel open",
+		zap.String("chainA", a.Chain.ChainID()),
+		zap.String("portA", a.PortID),
+		zap.String("channelA", a.ChannelID),
+		zap.String("chainB", b.Chain.ChainID()),
+		zap.String("portB", b.PortID),
+		zap.String("channelB", b.ChannelID),
+		zap.String("version", channelB.Version),
+	)
+	return nil
+}
+
+// connectionProofs - the connection of the endpoint, its client of the counterparty chain
+// and the proofs of them verified by the counterparty in the connection handshake
+type connectionProofs struct {
+	connection      connectiontypes.ConnectionEnd
+	clientState     *ibctm.ClientState
+	connectionProof []byte
+	clientProof     []byte
+	consensusProof  []byte
+	proofHeight     clienttypes.Height
+	consensusHeight clienttypes.Height
+}
+
+// connectionProofs - commit a block and query the connection proofs at the previous one,
+// returns the height of the block proving them
+func (e *Endpoint) connectionProofs() (int64, connectionProofs, error) {
+	var proofs connectionProofs
+	height, err := e.Chain.tick()
+	if err != nil {
+		return 0, proofs, err
+	}
+	cdc := e.Chain.service.Client().Codec.GetMarshaler()
+
+	connection, connectionProof, proofHeight, err := e.Chain.queryProof(host.ConnectionKey(e.ConnectionID), height-1)
+	if err != nil {
+		return 0, proofs, err
+	}
+	if err := cdc.Unmarshal(connection, &proofs.connection); err != nil {
+		return 0, proofs, fmt.Errorf("error decoding connection %s: %w", e.ConnectionID, err)
+	}
+
+	clientState, clientProof, _, err := e.Chain.queryProof(host.FullClientStateKey(e.ClientID), height-1)
+	if err != nil {
+		return 0, proofs, err
+	}
+	var decoded ibcexported.ClientState
+	if err := cdc.UnmarshalInterface(clientState, &decoded); err != nil {
+		return 0, proofs, fmt.Errorf("error decoding client %s: %w", e.ClientID, err)
+	}
+	tmClientState, ok := decoded.(*ibctm.ClientState)
+	if !ok {
+		return 0, proofs, fmt.Errorf("client %s is not a tendermint client", e.ClientID)
+	}
+
+	consensusHeight := tmClientState.LatestHeight
+	_, consensusProof, _, err := e.Chain.queryProof(host.FullConsensusStateKey(e.ClientID, consensusHeight), height-1)
+	if err != nil {
+		return 0, proofs, err
+	}
+
+	proofs.clientState = tmClientState
+	proofs.connectionProof = connectionProof
+	proofs.clientProof = clientProof
+	proofs.consensusProof = consensusProof
+	proofs.proofHeight = proofHeight
+	proofs.consensusHeight = consensusHeight
+	return height, proofs, nil
+}
+
+// channelProof - commit a block and query the channel of the endpoint with its proof
+// at the previous one, returns the height of the block proving it
+func (e *Endpoint) channelProof() (int64, channeltypes.Channel, []byte, error) {
+	var channel channeltypes.Channel
+	height, err := e.Chain.tick()
+	if err != nil {
+		return 0, channel, nil, err
+	}
+
+	value, proof, _, err := e.Chain.queryProof(host.ChannelKey(e.PortID, e.ChannelID), height-1)
+	if err != nil {
+		return 0, channel, nil, err
+	}
+	if err := e.Chain.service.Client().Codec.GetMarshaler().Unmarshal(value, &channel); err != nil {
+		return 0, channel, nil, fmt.Errorf("error decoding channel %s: %w", e.ChannelID, err)
+	}
+	return height, channel, proof, nil
+}
@@ -0,0 +1,192 @@
+package chainclient
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// DeployOptions - options of the manifest deployment.
+	// Contracts recorded in State are not deployed again unless their artifact,
+	// one of their dependencies or the chain changed, or they are listed in Force.
+	// Batch stores and instantiates every new contract in one transaction,
+	// it requires a single deployer because the code id is predicted.
+	DeployOptions struct {
+		GasPrice uint64
+		State    *DeployState
+		Force    []string
+		Batch    bool
+	}
+
+	// deployJob - contract to deploy with the outputs of its dependencies
+	deployJob struct {
+		spec        ContractSpec
+		values      PlaceholderValues
+		prev        *DeployedContract
+		depsChanged bool
+	}
+
+	// deployFunc - deploy the contract of the job, returns true if anything was deployed
+	deployFunc func(job deployJob) (DeployedContract, bool, error)
+
+	// deployResult - outputs of the deployed contract or the deployment error
+	deployResult struct {
+		spec     ContractSpec
+		deployed DeployedContract
+		changed  bool
+		err      error
+	}
+)
+
+// DeployManifestParallel deploys the contracts of the manifest as a dependency graph.
+// Every deployer signs with its own account and takes the next contract whose dependencies
+// are deployed, so independent contracts are uploaded and instantiated in parallel.
+// The {{ signer.address }} placeholder is the address of the first deployer.
+// If a contract fails, no more contracts are scheduled, the contracts in flight are finished
+// and recorded in opts.State, then the errors are returned.
+func DeployManifestParallel(m *Manifest, deployers []*ContractDeployer, opts DeployOptions) (map[string]DeployedContract, error) {
+	if len(deployers) == 0 {
+		return nil, errors.New("no deployers")
+	}
+	if opts.Batch && len(deployers) > 1 {
+		return nil, errors.New("batched deployment requires a single deployer")
+	}
+	log := deployers[0].log
+
+	order, err := m.Order()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(order))
+	for _, spec := range order {
+		names[spec.Name] = true
+	}
+	force := make(map[string]bool, len(opts.Force))
+	for _, name := range opts.Force {
+		if !names[name] {
+			return nil, fmt.Errorf("forced contract %q is not in the manifest", name)
+		}
+		force[name] = true
+	}
+
+	values := PlaceholderValues{
+		Contracts: make(map[string]DeployedContract, len(order)),
+		Vars:      m.Vars,
+		Signer:    deployers[0].signer.Address,
+		ChainID:   deployers[0].client.GetChainID(),
+		Denom:     deployers[0].client.GetDenom(),
+		Now:       time.Now(),
+	}
+
+	deploys := make([]deployFunc, len(deployers))
+	for i, d := range deployers {
+		deploys[i] = func(job deployJob) (DeployedContract, bool, error) {
+			return d.deployContract(job, opts)
+		}
+	}
+	return scheduleDeploy(order, values, deploys, force, opts.State, log)
+}
+
+// scheduleDeploy runs the deploy functions in parallel, each takes the next contract of the order
+// whose dependencies are deployed. Every deployed contract is recorded in the state.
+func scheduleDeploy(
+	order []ContractSpec,
+	values PlaceholderValues,
+	deploys []deployFunc,
+	force map[string]bool,
+	state *DeployState,
+	log Logger,
+) (map[string]DeployedContract, error) {
+	// number of not deployed dependencies and the dependents of every contract
+	pending := make(map[string]int, len(order))
+	dependents := make(map[string][]ContractSpec, len(order))
+	for _, spec := range order {
+		pending[spec.Name] = len(spec.DependsOn)
+		for _, dep := range spec.DependsOn {
+			dependents[dep] = append(dependents[dep], spec)
+		}
+	}
+
+	jobs := make(chan deployJob, len(order))
+	results := make(chan deployResult, len(order))
+	defer close(jobs)
+
+	for _, deploy := range deploys {
+		go func(deploy deployFunc) {
+			for job := range jobs {
+				deployed, changed, err := deploy(job)
+				results <- deployResult{spec: job.spec, deployed: deployed, changed: changed, err: err}
+			}
+		}(deploy)
+	}
+
+	// contracts deployed again in this run, their dependents are instantiated again
+	changed := make(map[string]bool, len(order))
+	inFlight := 0
+
+	// schedule sends the contract with a snapshot of the deployed contracts,
+	// the workers never read the map which is updated by the scheduler
+	schedule := func(spec ContractSpec) {
+		job := deployJob{spec: spec, values: values}
+		job.values.Contracts = make(map[string]DeployedContract, len(spec.DependsOn))
+		for _, dep := range spec.DependsOn {
+			job.values.Contracts[dep] = values.Contracts[dep]
+			job.depsChanged = job.depsChanged || changed[dep]
+		}
+		if state != nil && !force[spec.Name] {
+			if prev, ok := state.Get(spec.Name); ok {
+				job.prev = &prev
+			}
+		}
+		log.Info("Contract scheduled", zap.String("name", spec.Name), zap.Strings("depends_on", spec.DependsOn))
+		inFlight++
+		jobs <- job
+	}
+
+	for _, spec := range order {
+		if pending[spec.Name] == 0 {
+			schedule(spec)
+		}
+	}
+
+	// after an error the contracts in flight are still recorded, but no new contract is scheduled
+	var errs []error
+	for inFlight > 0 {
+		res := <-results
+		inFlight--
+		if res.err != nil {
+			log.Error("error deploying contract", zap.String("name", res.spec.Name), zap.Error(res.err))
+			errs = append(errs, fmt.Errorf("error deploying contract %s: %w", res.spec.Name, res.err))
+			continue
+		}
+		values.Contracts[res.spec.Name] = res.deployed
+		changed[res.spec.Name] = res.changed
+
+		if state != nil {
+			if err := state.Set(res.deployed); err != nil {
+				log.Error("error saving deployment state", zap.String("name", res.spec.Name), zap.Error(err))
+				errs = append(errs, fmt.Errorf("error saving deployment state of %s: %w", res.spec.Name, err))
+				continue
+			}
+		}
+
+		if len(errs) > 0 {
+			continue
+		}
+		for _, spec := range dependents[res.spec.Name] {
+			pending[spec.Name]--
+			if pending[spec.Name] == 0 {
+				schedule(spec)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return values.Contracts, nil
+}
@@ -0,0 +1,93 @@
+package chainclient
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestScheduleDeployResume(t *testing.T) {
+	m := &Manifest{Contracts: []ContractSpec{
+		{Name: "kernel"},
+		{Name: "vault", DependsOn: []string{"kernel"}},
+		{Name: "pool", DependsOn: []string{"kernel"}},
+	}}
+	order, err := m.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	statePath := filepath.Join(t.TempDir(), "deploy.state.json")
+
+	var (
+		mu       sync.Mutex
+		deployed []string
+		skipped  []string
+	)
+	// resumed deployments skip the contracts recorded in the state
+	deployer := func(fail string, started chan struct{}) deployFunc {
+		return func(job deployJob) (DeployedContract, bool, error) {
+			if job.prev != nil {
+				mu.Lock()
+				skipped = append(skipped, job.spec.Name)
+				mu.Unlock()
+				return *job.prev, false, nil
+			}
+			switch job.spec.Name {
+			case fail:
+				close(started)
+				return DeployedContract{}, false, errors.New("out of gas")
+			case "vault":
+				// the failing contract of the same layer is in flight
+				if started != nil {
+					<-started
+				}
+			}
+			mu.Lock()
+			deployed = append(deployed, job.spec.Name)
+			mu.Unlock()
+			return DeployedContract{Name: job.spec.Name, CodeID: uint64(len(job.spec.Name))}, true, nil
+		}
+	}
+
+	state, err := LoadDeployState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	deploys := []deployFunc{deployer("pool", started), deployer("pool", started)}
+	if _, err := scheduleDeploy(order, PlaceholderValues{Contracts: map[string]DeployedContract{}}, deploys, nil, state, zap.NewNop()); err == nil {
+		t.Fatal("expected error of the failed contract")
+	}
+
+	state, err = LoadDeployState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"kernel", "vault"} {
+		if _, ok := state.Get(name); !ok {
+			t.Fatalf("contract %s deployed in parallel with the failed one is not saved", name)
+		}
+	}
+	if _, ok := state.Get("pool"); ok {
+		t.Fatal("failed contract is saved")
+	}
+
+	deployed, skipped = nil, nil
+	deploys = []deployFunc{deployer("", nil), deployer("", nil)}
+	contracts, err := scheduleDeploy(order, PlaceholderValues{Contracts: map[string]DeployedContract{}}, deploys, nil, state, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contracts) != 3 {
+		t.Fatalf("%d contracts deployed, expected 3", len(contracts))
+	}
+	if len(deployed) != 1 || deployed[0] != "pool" {
+		t.Fatalf("deployed %v on resume, expected only pool", deployed)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("skipped %v on resume, expected kernel and vault", skipped)
+	}
+}
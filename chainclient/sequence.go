@@ -0,0 +1,133 @@
+package chainclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"go.uber.org/zap"
+)
+
+// maxSequenceRetries - number of times a transaction is signed again after a sequence mismatch
+const maxSequenceRetries = 5
+
+// sequenceMismatchRe matches the log of the sequence mismatch error and captures the expected sequence
+var sequenceMismatchRe = regexp.MustCompile(`account sequence mismatch, expected (\d+), got \d+`)
+
+// BroadcastMsgs - sign the messages with the next sequence of the account and broadcast
+// the transaction, waiting only for CheckTx. Many transactions of the same account may be in flight,
+// if the node reports a sequence mismatch (code 32) the sequence is refreshed and the transaction is signed again.
+// Pass AutoGas as gasPrice to estimate the gas by simulation.
+func (s *ChainService) BroadcastMsgs(signerName string, msgs []sdk.Msg, gasPrice uint64) (*coretypes.ResultBroadcastTx, error) {
+	if err := s.LoadAccount(signerName); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		acc, ok := s.client.GetAccount(signerName)
+		if !ok {
+			return nil, errors.New("account not found")
+		}
+
+		seq, err := s.client.ReserveSequence(signerName)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := s.broadcastWithSequence(acc, msgs, gasPrice, seq)
+		if err == nil {
+			return res, nil
+		}
+
+		if !isSequenceMismatch(err) || attempt == maxSequenceRetries {
+			s.client.ReleaseSequence(signerName, seq)
+			return nil, err
+		}
+
+		s.log.Warn("account sequence mismatch, retrying",
+			zap.String("signerName", signerName),
+			zap.Uint64("sequence", seq),
+			zap.Error(err),
+		)
+		if err := s.resyncSequence(signerName, err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// SendMsgs - broadcast the messages with BroadcastMsgs and wait for the transaction to be committed
+func (s *ChainService) SendMsgs(signerName string, msgs []sdk.Msg, gasPrice uint64) (*coretypes.ResultTx, error) {
+	res, err := s.BroadcastMsgs(signerName, msgs, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("Broadcast txHash", zap.Any("txHash", res.Hash))
+
+	return s.WaitTx(res.Hash)
+}
+
+// broadcastWithSequence - sign the transaction with the sequence and broadcast it with CheckTx
+func (s *ChainService) broadcastWithSequence(acc AccountInfo, msgs []sdk.Msg, gasPrice uint64, seq uint64) (*coretypes.ResultBroadcastTx, error) {
+	var (
+		gasLimit uint64
+		fee      sdk.Coins
+	)
+	if gasPrice == AutoGas {
+		simBytes, err := s.client.simulateBatchTxBytes(acc, msgs, seq)
+		if err != nil {
+			return nil, err
+		}
+		gasUsed, err := s.simulate(simBytes)
+		if err != nil {
+			return nil, err
+		}
+		gasLimit, fee = s.client.CalculateFee(gasUsed)
+	} else {
+		var err error
+		if gasLimit, fee, err = s.client.fixedFee(gasPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	txBytes, err := s.client.signBatchTx(acc, msgs, gasLimit, fee, seq)
+	if err != nil {
+		return nil, err
+	}
+	if len(txBytes) > s.maxTxBytes {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTxTooLarge, len(txBytes), s.maxTxBytes)
+	}
+
+	res, err := s.c.BroadcastTxSync(context.Background(), txBytes)
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 0 {
+		return nil, errorsmod.ABCIError(res.Codespace, res.Code, res.Log)
+	}
+
+	return res, nil
+}
+
+// resyncSequence - set the sequence expected by the node, taken from the error log
+// or queried from the chain if the log has no sequence
+func (s *ChainService) resyncSequence(signerName string, mismatch error) error {
+	if m := sequenceMismatchRe.FindStringSubmatch(mismatch.Error()); m != nil {
+		seq, err := strconv.ParseUint(m[1], 10, 64)
+		if err == nil {
+			return s.client.SetSequence(signerName, seq)
+		}
+	}
+
+	return s.UpdateAccountSequence(signerName)
+}
+
+// isSequenceMismatch - the transaction was rejected because of the wrong sequence
+func isSequenceMismatch(err error) bool {
+	return errors.Is(err, sdkerrors.ErrWrongSequence) || sequenceMismatchRe.MatchString(err.Error())
+}
@@ -0,0 +1,108 @@
+package chainclient
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"go.uber.org/zap"
+)
+
+func TestReserveSequence(t *testing.T) {
+	client := NewChainClient(300000, DefaultPrefix, DefaultChainID, DefaultDenom, zap.NewNop())
+	client.AddOfflineAccount("user1", User1Mnemonic, 5, 1)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[uint64]bool)
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seq, err := client.ReserveSequence("user1")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[seq] {
+				t.Errorf("sequence %d reserved twice", seq)
+			}
+			seen[seq] = true
+		}()
+	}
+	wg.Wait()
+
+	// only the last reserved sequence is given back
+	client.ReleaseSequence("user1", 10)
+	if acc, _ := client.GetAccount("user1"); acc.Sequence != 25 {
+		t.Fatalf("sequence %d, expected 25", acc.Sequence)
+	}
+	client.ReleaseSequence("user1", 24)
+	if acc, _ := client.GetAccount("user1"); acc.Sequence != 24 {
+		t.Fatalf("sequence %d, expected 24", acc.Sequence)
+	}
+}
+
+func TestIsSequenceMismatch(t *testing.T) {
+	checkTxErr := errorsmod.ABCIError(sdkerrors.RootCodespace, 32, "account sequence mismatch, expected 7, got 5: incorrect account sequence")
+	if !errors.Is(checkTxErr, sdkerrors.ErrWrongSequence) || !isSequenceMismatch(checkTxErr) {
+		t.Fatal("expected sequence mismatch")
+	}
+
+	simulateErr := errors.New("simulation failed: account sequence mismatch, expected 7, got 5: incorrect account sequence")
+	if !isSequenceMismatch(simulateErr) {
+		t.Fatal("expected sequence mismatch")
+	}
+	if m := sequenceMismatchRe.FindStringSubmatch(simulateErr.Error()); m == nil || m[1] != "7" {
+		t.Fatalf("unexpected match %v", m)
+	}
+
+	if isSequenceMismatch(errors.New("insufficient funds")) {
+		t.Fatal("unexpected sequence mismatch")
+	}
+}
+
+func TestFixedFee(t *testing.T) {
+	client := NewChainClient(300000, DefaultPrefix, DefaultChainID, DefaultDenom, zap.NewNop())
+	if AutoGas == 0 {
+		t.Fatal("AutoGas must differ from the default gas price 0")
+	}
+
+	// a gas price of 0 is the default fee, the gas limit is twice the fee
+	gasLimit, fee, err := client.fixedFee(0)
+	if err != nil || gasLimit != 200000 || fee.AmountOf(DefaultDenom).Int64() != 100000 {
+		t.Fatalf("gas limit %d fee %s error %v, expected 200000 and 100000%s", gasLimit, fee, err, DefaultDenom)
+	}
+	gasLimit, fee, err = client.fixedFee(5000)
+	if err != nil || gasLimit != 10000 || fee.AmountOf(DefaultDenom).Int64() != 5000 {
+		t.Fatalf("gas limit %d fee %s error %v, expected 10000 and 5000%s", gasLimit, fee, err, DefaultDenom)
+	}
+	if _, _, err := client.fixedFee(maxGasPrice); err != nil {
+		t.Fatal(err)
+	}
+
+	// the gas is not simulated offline and the fee amount is an int64
+	client.AddOfflineAccount("user1", User1Mnemonic, 0, 1)
+	acc, _ := client.GetAccount("user1")
+	msg := &banktypes.MsgSend{
+		FromAddress: acc.Address,
+		ToAddress:   acc.Address,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin(DefaultDenom, 1)),
+	}
+	for _, gasPrice := range []uint64{AutoGas, maxGasPrice + 1} {
+		if _, err := client.GetSignedTxBytes("user1", msg, gasPrice); err == nil {
+			t.Fatalf("expected error for gas price %d", gasPrice)
+		}
+	}
+	if _, err := client.GetSignedTxBytes("user1", msg, 0); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,40 @@
+package chainclient
+
+import (
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+)
+
+// ChainService - interacts with the blockchain through the rpc client,
+// the transactions are signed by the chain client.
+type ChainService struct {
+	client     *ChainClient
+	c          *rpchttp.HTTP
+	log        Logger
+	maxTxBytes int
+}
+
+// NewChainService - create a new chain service.
+func NewChainService(client *ChainClient, c *rpchttp.HTTP, log Logger) *ChainService {
+	return &ChainService{
+		client:     client,
+		c:          c,
+		log:        log,
+		maxTxBytes: DefaultMaxTxBytes,
+	}
+}
+
+// SetMaxTxBytes - set the max size of the batched transactions,
+// it must not exceed the mempool max_tx_bytes of the nodes.
+func (s *ChainService) SetMaxTxBytes(maxTxBytes int) {
+	s.maxTxBytes = maxTxBytes
+}
+
+// Client - get the chain client used to sign the transactions.
+func (s *ChainService) Client() *ChainClient {
+	return s.client
+}
+
+// RPC - get the rpc client.
+func (s *ChainService) RPC() *rpchttp.HTTP {
+	return s.c
+}
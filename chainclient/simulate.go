@@ -0,0 +1,92 @@
+package chainclient
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"go.uber.org/zap"
+)
+
+// AutoGas - gas price value which makes the gas limit
+// estimated by the simulation instead of derived from the fee,
+// a gas price of 0 keeps the default fixed fee
+const AutoGas uint64 = math.MaxUint64
+
+// maxGasPrice - highest gas price of a fixed fee, the fee amount is an int64
+const maxGasPrice uint64 = math.MaxInt64
+
+// Simulate - simulate the transaction and return the gas used
+func (s *ChainService) Simulate(signerName string, msg sdk.Msg) (uint64, error) {
+	return s.SimulateBatch(signerName, []sdk.Msg{msg})
+}
+
+// SimulateBatch - simulate the transaction with all the messages and return the gas used
+func (s *ChainService) SimulateBatch(signerName string, msgs []sdk.Msg) (uint64, error) {
+	if err := s.LoadAccount(signerName); err != nil {
+		return 0, err
+	}
+
+	txBytes, err := s.client.GetSimulateBatchTxBytes(signerName, msgs)
+	if err != nil {
+		s.log.Error("error getting simulate tx bytes", zap.Error(err))
+		return 0, err
+	}
+
+	return s.simulate(txBytes)
+}
+
+// simulate - simulate the encoded transaction against the mempool state of the node
+func (s *ChainService) simulate(txBytes []byte) (uint64, error) {
+	var (
+		queryPath = "/cosmos.tx.v1beta1.Service/Simulate"
+		req       = &txtypes.SimulateRequest{
+			TxBytes: txBytes,
+		}
+		res = &txtypes.SimulateResponse{}
+	)
+
+	if err := s.query(queryPath, req, res); err != nil {
+		return 0, fmt.Errorf("simulation failed: %w", err)
+	}
+
+	if res.GasInfo == nil || res.GasInfo.GasUsed == 0 {
+		return 0, errors.New("simulation returned no gas info")
+	}
+
+	return res.GasInfo.GasUsed, nil
+}
+
+// SignTx - sign the transaction, if gasPrice is AutoGas the gas limit
+// is estimated by the simulation and the fee is calculated from the min gas price
+func (s *ChainService) SignTx(signerName string, msg sdk.Msg, gasPrice uint64) ([]byte, error) {
+	return s.SignBatchTx(signerName, []sdk.Msg{msg}, gasPrice)
+}
+
+// SignBatchTx - sign the transaction with all the messages, the gas is estimated
+// for the whole transaction if gasPrice is AutoGas
+func (s *ChainService) SignBatchTx(signerName string, msgs []sdk.Msg, gasPrice uint64) ([]byte, error) {
+	if err := s.LoadAccount(signerName); err != nil {
+		return nil, err
+	}
+
+	if gasPrice != AutoGas {
+		return s.client.GetSignedBatchTxBytes(signerName, msgs, gasPrice)
+	}
+
+	gasUsed, err := s.SimulateBatch(signerName, msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, fee := s.client.CalculateFee(gasUsed)
+	s.log.Info("Gas estimated",
+		zap.Uint64("gasUsed", gasUsed),
+		zap.Uint64("gasLimit", gasLimit),
+		zap.String("fee", fee.String()),
+	)
+
+	return s.client.GetSignedBatchTxBytesWithGas(signerName, msgs, gasLimit, fee)
+}
@@ -0,0 +1,89 @@
+package chainclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DeployState - outputs of the deployed contracts, saved after every contract,
+// so a failed deployment is resumed without uploading the deployed contracts again.
+type DeployState struct {
+	path string
+	mu   sync.Mutex
+
+	Contracts map[string]DeployedContract `json:"contracts"`
+}
+
+// LoadDeployState - load the state file, the state is empty if the file does not exist.
+func LoadDeployState(path string) (*DeployState, error) {
+	st := &DeployState{
+		path:      path,
+		Contracts: make(map[string]DeployedContract),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("error parsing state %s: %w", path, err)
+	}
+	if st.Contracts == nil {
+		st.Contracts = make(map[string]DeployedContract)
+	}
+	return st, nil
+}
+
+// Get - get the deployed contract by name.
+func (st *DeployState) Get(name string) (DeployedContract, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	contract, ok := st.Contracts[name]
+	return contract, ok
+}
+
+// Set - record the deployed contract and save the state file.
+func (st *DeployState) Set(contract DeployedContract) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.Contracts[contract.Name] = contract
+	return st.save()
+}
+
+// save - write the state to a temporary file and rename it,
+// so the state file is never left half written
+func (st *DeployState) save() error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(st.path), filepath.Base(st.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error saving state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error saving state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error saving state: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), st.path); err != nil {
+		return fmt.Errorf("error saving state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package chainclient
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/codec"
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/consideritdone/landslide-runner/chainclient/crypto/ethsecp256k1"
+)
+
+// Defaults of the landslide test chain
+const (
+	DefaultPrefix  = "wasm"
+	DefaultChainID = "landslide-test"
+	DefaultDenom   = "stake"
+)
+
+// Test accounts funded in the genesis of the landslide test chain
+const (
+	User1Mnemonic = "tip yard art tape orchard universe angle flame wave gadget raven coyote crater ethics able evoke luxury predict leopard delay peanut embody blast soap"
+	User2Mnemonic = "thumb scorpion sting term fiscal dream nephew fitness session nation happy attitude canyon raise omit town garment enroll term trial math neglect truck feature"
+)
+
+// defaultGasAdjustment and defaultMinGasPrice are used to calculate
+// the gas limit and the fee of the simulated transactions
+const defaultGasAdjustment = 1.3
+
+var defaultMinGasPrice = math.LegacyMustNewDecFromStr("0.5")
+
+var (
+	prefixesMu sync.Mutex
+	// accountPrefix - prefix applied to the sdk config, empty until SetPrefixes is called
+	accountPrefix string
+)
+
+// SetPrefixes - set prefix for accounts and validators.
+// The sdk config is sealed, so setting a different prefix afterwards is an error.
+func SetPrefixes(accountAddressPrefix string) error {
+	prefixesMu.Lock()
+	defer prefixesMu.Unlock()
+	if accountPrefix != "" {
+		if accountPrefix != accountAddressPrefix {
+			return fmt.Errorf("address prefix is already set to %q, can not set %q", accountPrefix, accountAddressPrefix)
+		}
+		return nil
+	}
+
+	// Set prefixes
+	accountPubKeyPrefix := accountAddressPrefix + "pub"
+	validatorAddressPrefix := accountAddressPrefix + "valoper"
+	validatorPubKeyPrefix := accountAddressPrefix + "valoperpub"
+	consNodeAddressPrefix := accountAddressPrefix + "valcons"
+	consNodePubKeyPrefix := accountAddressPrefix + "valconspub"
+
+	// Set and seal config
+	config := sdk.GetConfig()
+	config.SetBech32PrefixForAccount(accountAddressPrefix, accountPubKeyPrefix)
+	config.SetBech32PrefixForValidator(validatorAddressPrefix, validatorPubKeyPrefix)
+	config.SetBech32PrefixForConsensusNode(consNodeAddressPrefix, consNodePubKeyPrefix)
+	config.Seal()
+	accountPrefix = accountAddressPrefix
+	return nil
+}
+
+// getProtoCodec - create new proto Codec for keyring.
+func getProtoCodec() codec.Codec {
+	registry := cdctypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	ethsecp256k1.RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}
+
+// NewKeyring - create new keyring.
+func NewKeyring() (keyring.Keyring, error) {
+	return NewKeyringWithBackend(keyring.BackendMemory, "", nil)
+}
+
+// NewKeyringWithBackend - create new keyring with the os, file, test or memory backend.
+// The file and test backends store the keys in dir, the file backend reads the keyring
+// password from input.
+func NewKeyringWithBackend(backend, dir string, input io.Reader) (keyring.Keyring, error) {
+	kr, err := keyring.New(sdk.KeyringServiceName(), backend, dir, input, getProtoCodec(), keyringOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s keyring: %w", backend, err)
+	}
+
+	return kr, nil
+}
+
+// DefaultKeyringDir - directory of the persistent keyring, ~/.landslide
+func DefaultKeyringDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".landslide"
+	}
+	return filepath.Join(home, ".landslide")
+}
@@ -0,0 +1,148 @@
+package chainclient
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/CosmWasm/wasmd/x/wasm"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+// DeployContract - deploy wasm contract,
+// pass AutoGas as gasPrice to estimate the gas by simulation
+//
+// upload "./artifacts/andromeda_kernel.wasm" AutoGas
+func (s *ChainService) DeployContract(signerName string, fileName string, gasPrice uint64) (*coretypes.ResultTx, error) {
+	WASMByteCode, err := s.readArtifact(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		s.log.Fatal("account not found", zap.String("signerName", signerName))
+		return nil, errors.New("account not found")
+	}
+
+	msgStore := &wasm.MsgStoreCode{
+		Sender:       acc.Address,
+		WASMByteCode: WASMByteCode,
+		InstantiatePermission: &types.AccessConfig{
+			Permission: types.AccessTypeEverybody,
+		},
+	}
+
+	// sign with the next sequence, broadcast and wait for the transaction to be committed
+	s.log.Info("Deploying wasm contract", zap.String("fileName", fileName))
+	deployResTx, err := s.SendMsgs(signerName, []sdk.Msg{msgStore}, gasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("error deploying wasm contract %s: %w", fileName, err)
+	}
+
+	return deployResTx, nil
+}
+
+// readArtifact - read the wasm file and verify it against the checksums file
+func (s *ChainService) readArtifact(fileName string) ([]byte, error) {
+	WASMByteCode, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading wasm file: %w", err)
+	}
+
+	if len(WASMByteCode) == 0 {
+		return nil, fmt.Errorf("wasm file %s is empty", fileName)
+	}
+
+	checksum, err := VerifyArtifact(fileName, WASMByteCode)
+	switch {
+	case errors.Is(err, ErrNoChecksums):
+		s.log.Warn("artifact is not verified, no checksums file", zap.String("fileName", fileName))
+	case err != nil:
+		return nil, fmt.Errorf("error verifying wasm file %s: %w", fileName, err)
+	default:
+		s.log.Info("Artifact checksum verified", zap.String("fileName", fileName), zap.String("checksum", checksum))
+	}
+
+	return WASMByteCode, nil
+}
+
+// InstantiateOptions - label, admin and funds of the instantiated contract
+type InstantiateOptions struct {
+	Label string
+	Admin string
+	Funds sdk.Coins
+}
+
+// InstantiateContract - instantiate wasm contract
+func (s *ChainService) InstantiateContract(signerName string, codeID uint64, msg []byte, gasPrice uint64) (*coretypes.ResultTx, error) {
+	return s.InstantiateContractWithOptions(signerName, codeID, msg, InstantiateOptions{
+		Label: "testing",
+		Funds: sdk.NewCoins(sdk.NewInt64Coin(s.client.GetDenom(), 10000)),
+	}, gasPrice)
+}
+
+// InstantiateContractWithOptions - instantiate wasm contract with the given label, admin and funds
+func (s *ChainService) InstantiateContractWithOptions(
+	signerName string,
+	codeID uint64,
+	msg []byte,
+	opts InstantiateOptions,
+	gasPrice uint64,
+) (*coretypes.ResultTx, error) {
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		s.log.Fatal("account not found", zap.String("signerName", signerName))
+		return nil, errors.New("account not found")
+	}
+
+	// instantiate wasm contract
+	msgInst := &wasm.MsgInstantiateContract{
+		Sender: acc.Address,
+		Admin:  opts.Admin,
+		CodeID: codeID,
+		Label:  opts.Label,
+		Msg:    msg,
+		Funds:  opts.Funds,
+	}
+
+	// sign with the next sequence, broadcast and wait for the transaction to be committed
+	s.log.Info("MsgInstantiateContract wasm contract", zap.Uint64("codeID", codeID), zap.String("label", opts.Label))
+	resTx, err := s.SendMsgs(signerName, []sdk.Msg{msgInst}, gasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("error instantiating code %d: %w", codeID, err)
+	}
+
+	s.log.Info("Success! Instantiating wasm contract committed")
+
+	return resTx, nil
+}
+
+// ExecuteContract - execute the wasm contract with the json message,
+// the funds are sent to the contract with the message
+func (s *ChainService) ExecuteContract(
+	signerName string,
+	contractAddress string,
+	msg []byte,
+	funds sdk.Coins,
+	gasPrice uint64,
+) (*coretypes.ResultTx, error) {
+	acc, ok := s.client.GetAccount(signerName)
+	if !ok {
+		s.log.Fatal("account not found", zap.String("signerName", signerName))
+		return nil, errors.New("account not found")
+	}
+
+	msgExec := &wasm.MsgExecuteContract{
+		Sender:   acc.Address,
+		Contract: contractAddress,
+		Msg:      msg,
+		Funds:    funds,
+	}
+
+	s.log.Info("MsgExecuteContract wasm contract", zap.String("contract", contractAddress), zap.String("funds", funds.String()))
+	return s.SendMsgs(signerName, []sdk.Msg{msgExec}, gasPrice)
+}
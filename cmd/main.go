@@ -31,10 +31,15 @@ var (
 
 	//go:embed data/kvstore.json
 	genesisKvStore []byte
+	// template rendered by internal.RenderGenesis
 	//go:embed data/wasm.json
 	genesisWasm []byte
-	//go:embed data/testdata/nameservice.wasm.hex
-	nameserviceDeployHex string
+	// generated from tools/payload_gen/scenarios/wasm.yaml with `make fixtures-wasm`
+	//go:embed data/testdata/wasm_fixtures.json
+	wasmFixtures []byte
+	// gentx of the second chain of the IBC tests, generated from tools/payload_gen/scenarios/ibc.yaml with `make fixtures-ibc`
+	//go:embed data/testdata/ibc_fixtures.json
+	ibcFixtures []byte
 )
 
 func main() {
@@ -54,6 +59,7 @@ func main() {
 
 	binaryPath := "/tmp/e2e-test-landslide/avalanchego"
 	workDir := "/tmp/e2e-test-landslide/nodes"
+	referenceHome := "/tmp/e2e-test-landslide/cometbft-home"
 
 	app := &cli.App{
 		Name:  "main",
@@ -91,7 +97,36 @@ func main() {
 								fmt.Println(err)
 								os.Exit(1)
 							}
-							_, err = runNodes(log, binaryPath, genesisWasm, nw)
+							genesis, err := internal.RenderGenesis(genesisWasm, internal.DefaultGenesisParams())
+							if err != nil {
+								log.Fatal("error rendering genesis", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							_, err = runNodes(log, binaryPath, genesis, nw)
+							if err != nil {
+								log.Fatal("error starting nodes", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+
+							internal.GracefulShutdown(nw, log)
+							return nil
+						},
+					},
+					{
+						Name:  "ibc",
+						Usage: "run two CosmWasm chains with different chain IDs as subnets",
+						Action: func(cCtx *cli.Context) error {
+							geneses, err := renderIBCGeneses()
+							if err != nil {
+								log.Fatal("error rendering genesis", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							nw, err := createNetwork(log, binaryPath, workDir)
+							if err != nil {
+								fmt.Println(err)
+								os.Exit(1)
+							}
+							_, _, err = runChains(log, binaryPath, geneses, nw)
 							if err != nil {
 								log.Fatal("error starting nodes", zap.Error(err))
 								return cli.Exit("exiting", 1)
@@ -139,13 +174,44 @@ func main() {
 					{
 						Name:  "wasm",
 						Usage: "wasm end-to-end tests",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "gas-report",
+								Usage: "file to write the gas used per message type to",
+							},
+							&cli.DurationFlag{
+								Name:  "voting-period",
+								Usage: "gov voting period rendered into the genesis, the proposal test waits for it",
+								Value: 20 * time.Second,
+							},
+							&cli.DurationFlag{
+								Name:  "unbonding-time",
+								Usage: "staking unbonding time rendered into the genesis, the staking test waits for it",
+								Value: 30 * time.Second,
+							},
+						},
 						Action: func(cCtx *cli.Context) error {
+							fixtures, err := internal.LoadFixtures(wasmFixtures)
+							if err != nil {
+								log.Fatal("error loading wasm fixtures", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+
+							genesisParams := internal.DefaultGenesisParams()
+							genesisParams.VotingPeriod = cCtx.Duration("voting-period")
+							genesisParams.UnbondingTime = cCtx.Duration("unbonding-time")
+							genesis, err := internal.RenderGenesis(genesisWasm, genesisParams)
+							if err != nil {
+								log.Fatal("error rendering genesis", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+
 							nw, err := createNetwork(log, binaryPath, workDir)
 							if err != nil {
 								fmt.Println(err)
 								os.Exit(1)
 							}
-							rpcs, err := runNodes(log, binaryPath, genesisWasm, nw)
+							rpcs, err := runNodes(log, binaryPath, genesis, nw)
 							if err != nil {
 								log.Fatal("error starting nodes", zap.Error(err))
 								return cli.Exit("exiting", 1)
@@ -159,13 +225,199 @@ func main() {
 							internal.RunWASMTests(
 								rpcs,
 								log,
-								nameserviceDeployHex,
+								fixtures,
+								genesisParams,
+								cCtx.String("gas-report"),
 							)
 
 							internal.GracefulShutdown(nw, log)
 							return nil
 						},
 					},
+					{
+						Name:  "ibc",
+						Usage: "IBC end-to-end tests between two wasm chains",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "ibc-tool",
+								Usage: "directory of the IBC test tool with the relayer",
+								Value: "../tools/ibc",
+							},
+						},
+						Action: func(cCtx *cli.Context) error {
+							geneses, err := renderIBCGeneses()
+							if err != nil {
+								log.Fatal("error rendering genesis", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+
+							nw, err := createNetwork(log, binaryPath, workDir)
+							if err != nil {
+								fmt.Println(err)
+								os.Exit(1)
+							}
+							defer func() {
+								if err := nw.Stop(context.Background()); err != nil {
+									log.Error("error while shutting down network", zap.Error(err))
+								}
+							}()
+
+							rpcs, _, err := runChains(log, binaryPath, geneses, nw)
+							if err != nil {
+								log.Fatal("error starting nodes", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+
+							paramsA, paramsB, err := ibcGenesisParams()
+							if err != nil {
+								log.Fatal("error loading IBC genesis params", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							err = internal.RunIBCTests(cCtx.String("ibc-tool"), rpcs[0][0], paramsA.ChainID, rpcs[1][0], paramsB.ChainID, log)
+							if err != nil {
+								log.Fatal("IBC tests failed", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "deploy",
+						Usage: "contract deployment end-to-end tests of tools/deploy on a wasm chain",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "deploy-tool",
+								Usage: "directory of the deployment tool",
+								Value: "../tools/deploy",
+							},
+							&cli.StringFlag{
+								Name:  "manifest",
+								Usage: "deployment manifest, relative to the tool directory",
+								Value: "testdata/e2e.yaml",
+							},
+						},
+						Action: func(cCtx *cli.Context) error {
+							genesisParams := internal.DefaultGenesisParams()
+							genesis, err := internal.RenderGenesis(genesisWasm, genesisParams)
+							if err != nil {
+								log.Fatal("error rendering genesis", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+
+							nw, err := createNetwork(log, binaryPath, workDir)
+							if err != nil {
+								fmt.Println(err)
+								os.Exit(1)
+							}
+							defer func() {
+								if err := nw.Stop(context.Background()); err != nil {
+									log.Error("error while shutting down network", zap.Error(err))
+								}
+							}()
+
+							rpcs, err := runNodes(log, binaryPath, genesis, nw)
+							if err != nil {
+								log.Fatal("error starting nodes", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							if len(rpcs) == 0 {
+								log.Fatal("no rpcs")
+								return cli.Exit("exiting", 1)
+							}
+
+							err = internal.RunDeployTests(cCtx.String("deploy-tool"), cCtx.String("manifest"), rpcs[0], genesisParams.ChainID, log)
+							if err != nil {
+								log.Fatal("deploy tests failed", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							return nil
+						},
+					},
+					{
+						Name:  "events",
+						Usage: "websocket event stream end-to-end tests",
+						Action: func(cCtx *cli.Context) error {
+							nw, err := createNetwork(log, binaryPath, workDir)
+							if err != nil {
+								fmt.Println(err)
+								os.Exit(1)
+							}
+							defer func() {
+								if err := nw.Stop(context.Background()); err != nil {
+									log.Error("error while shutting down network", zap.Error(err))
+								}
+							}()
+
+							rpcs, err := runNodesByName(log, binaryPath, genesisKvStore, nw)
+							if err != nil {
+								log.Fatal("error starting nodes", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							if len(rpcs) == 0 {
+								log.Fatal("no rpcs")
+								return cli.Exit("exiting", 1)
+							}
+
+							internal.RunEventTests(nw, rpcs, log, 20)
+							return nil
+						},
+					},
+					{
+						Name:  "diff",
+						Usage: "compare kvstore rpc responses against a reference cometbft node",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "cometbft-binary",
+								Usage: "path to the cometbft binary used as the reference node",
+								Value: "/tmp/e2e-test-landslide/cometbft",
+							},
+							&cli.StringFlag{
+								Name:  "allowlist",
+								Usage: "json file with additional known differences",
+							},
+						},
+						Action: func(cCtx *cli.Context) error {
+							allowed, err := internal.LoadAllowedDiffs(cCtx.String("allowlist"))
+							if err != nil {
+								log.Fatal("error loading allowlist", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+
+							ref, err := internal.StartReferenceNode(cCtx.String("cometbft-binary"), referenceHome, log)
+							if err != nil {
+								log.Fatal("error starting reference node", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							defer ref.Stop()
+
+							nw, err := createNetwork(log, binaryPath, workDir)
+							if err != nil {
+								fmt.Println(err)
+								os.Exit(1)
+							}
+							defer func() {
+								if err := nw.Stop(context.Background()); err != nil {
+									log.Error("error while shutting down network", zap.Error(err))
+								}
+							}()
+
+							rpcs, err := runNodes(log, binaryPath, genesisKvStore, nw)
+							if err != nil {
+								log.Fatal("error starting nodes", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							if len(rpcs) == 0 {
+								log.Fatal("no rpcs")
+								return cli.Exit("exiting", 1)
+							}
+
+							if err := internal.RunDiffTests(rpcs[0], ref.RPCURL, allowed, log); err != nil {
+								log.Fatal("differential tests failed", zap.Error(err))
+								return cli.Exit("exiting", 1)
+							}
+							return nil
+						},
+					},
 					{
 						Name:  "osmosis",
 						Usage: "osmosis end-to-end tests",
@@ -186,56 +438,87 @@ func main() {
 }
 
 func runNodes(log logging.Logger, binaryPath string, genesis []byte, nw network.Network) ([]string, error) {
+	rpcUrls, _, err := runChains(log, binaryPath, [][]byte{genesis}, nw)
+	if err != nil {
+		return nil, err
+	}
+	return rpcUrls[0], nil
+}
+
+// runNodesByName deploys a landslidevm chain on all the nodes, returns the rpc url of the chain by node name.
+func runNodesByName(log logging.Logger, binaryPath string, genesis []byte, nw network.Network) (map[string]string, error) {
+	rpcUrls, nodeNames, err := runChains(log, binaryPath, [][]byte{genesis}, nw)
+	if err != nil {
+		return nil, err
+	}
+	rpcs := make(map[string]string, len(nodeNames))
+	for i, name := range nodeNames {
+		rpcs[name] = rpcUrls[0][i]
+	}
+	return rpcs, nil
+}
+
+// runChains deploys a landslidevm chain of every genesis on all the nodes, returns the rpc urls of each chain
+// in the order of the returned node names. Every chain of every node serves its own grpc port.
+func runChains(log logging.Logger, binaryPath string, geneses [][]byte, nw network.Network) ([][]string, []string, error) {
 	// Wait until the nodes in the network are ready
 	if err := internal.Await(nw, log, healthyTimeout); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Add some chain
 	nodeNames, err := nw.GetNodeNames()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	vmCfg := internal.Config{}
-	vmCfg.VMConfig.SetDefaults()
-	appCfg := internal.AppConfig{}
-
-	perNodeChainConfig := make(map[string][]byte)
-	grpcPort := defaultGrpcPort
 	for i := range nodeNames {
 		node, err := nw.GetNode(nodeNames[i])
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if _, err := internal.Copy(
 			fmt.Sprintf("%s/plugins/%s", binaryPath, subnetFileName),
 			fmt.Sprintf("%s/plugins/%s", node.GetDataDir(), subnetFileName),
 		); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+	}
 
-		appCfg.GRPCPort = grpcPort
-		appCfg.RPCPort = node.GetAPIPort()
-
-		// Marshal the AppConfig into JSON
-		appConfigJSON, err := json.Marshal(appCfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal AppConfig: %w", err)
-		}
-		vmCfg.AppConfig = appConfigJSON
+	vmCfg := internal.Config{}
+	vmCfg.VMConfig.SetDefaults()
+	appCfg := internal.AppConfig{}
 
-		cfgBytes, err := json.Marshal(vmCfg)
-		if err != nil {
-			return nil, err
+	specs := make([]network.BlockchainSpec, len(geneses))
+	grpcPort := defaultGrpcPort
+	for c, genesis := range geneses {
+		perNodeChainConfig := make(map[string][]byte)
+		for i := range nodeNames {
+			node, err := nw.GetNode(nodeNames[i])
+			if err != nil {
+				return nil, nil, err
+			}
+
+			appCfg.GRPCPort = grpcPort
+			appCfg.RPCPort = node.GetAPIPort()
+
+			// Marshal the AppConfig into JSON
+			appConfigJSON, err := json.Marshal(appCfg)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal AppConfig: %w", err)
+			}
+			vmCfg.AppConfig = appConfigJSON
+
+			cfgBytes, err := json.Marshal(vmCfg)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			perNodeChainConfig[node.GetName()] = cfgBytes
+			grpcPort++
 		}
 
-		perNodeChainConfig[node.GetName()] = cfgBytes
-		grpcPort++
-	}
-
-	chains, err := nw.CreateBlockchains(context.Background(), []network.BlockchainSpec{
-		{
+		specs[c] = network.BlockchainSpec{
 			VMName:      "landslidevm",
 			Genesis:     genesis,
 			ChainConfig: []byte{},
@@ -244,36 +527,66 @@ func runNodes(log logging.Logger, binaryPath string, genesis []byte, nw network.
 				Participants: nodeNames,
 			},
 			PerNodeChainConfig: perNodeChainConfig,
-		},
-	})
+		}
+	}
+
+	chains, err := nw.CreateBlockchains(context.Background(), specs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Wait until the nodes in the network are ready
 	if err := internal.Await(nw, log, healthyTimeout); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	rpcUrls := make([]string, len(nodeNames))
-	grpcUrls := make([]string, len(nodeNames))
+	rpcUrls := make([][]string, len(chains))
 	grpcPort = defaultGrpcPort
-	for i := range nodeNames {
-		node, err := nw.GetNode(nodeNames[i])
-		if err != nil {
-			return nil, err
+	for c := range chains {
+		rpcUrls[c] = make([]string, len(nodeNames))
+		for i := range nodeNames {
+			node, err := nw.GetNode(nodeNames[i])
+			if err != nil {
+				return nil, nil, err
+			}
+			rpcUrls[c][i] = fmt.Sprintf("http://127.0.0.1:%d/ext/bc/%s/rpc", node.GetAPIPort(), chains[c])
+			log.Info("subnet rpc url",
+				zap.String("chain", chains[c].String()),
+				zap.String("node", nodeNames[i]),
+				zap.String("rpc", rpcUrls[c][i]),
+				zap.String("grpc", fmt.Sprintf("http://127.0.0.1:%d", grpcPort)),
+			)
+			grpcPort++
 		}
-		rpcUrls[i] = fmt.Sprintf("http://127.0.0.1:%d/ext/bc/%s/rpc", node.GetAPIPort(), chains[0])
-		grpcUrls[i] = fmt.Sprintf("http://127.0.0.1:%d", grpcPort)
-		log.Info("subnet rpc url",
-			zap.String("node", nodeNames[i]),
-			zap.String("rpc", rpcUrls[i]),
-			zap.String("grpc", grpcUrls[i]),
-		)
-		grpcPort++
 	}
 
-	return rpcUrls, nil
+	return rpcUrls, nodeNames, nil
+}
+
+// ibcGenesisParams returns the genesis params of the two chains of the IBC tests with the gentx of the fixtures
+func ibcGenesisParams() (internal.GenesisParams, internal.GenesisParams, error) {
+	fixtures, err := internal.LoadFixtures(ibcFixtures)
+	if err != nil {
+		return internal.GenesisParams{}, internal.GenesisParams{}, err
+	}
+	return internal.IBCGenesisParams(fixtures)
+}
+
+// renderIBCGeneses renders the wasm genesis of the two chains of the IBC tests
+func renderIBCGeneses() ([][]byte, error) {
+	paramsA, paramsB, err := ibcGenesisParams()
+	if err != nil {
+		return nil, err
+	}
+	genesisA, err := internal.RenderGenesis(genesisWasm, paramsA)
+	if err != nil {
+		return nil, err
+	}
+	genesisB, err := internal.RenderGenesis(genesisWasm, paramsB)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{genesisA, genesisB}, nil
 }
 
 func createNetwork(log logging.Logger, binaryPath string, workDir string) (network.Network, error) {
@@ -8,6 +8,7 @@ require (
 	github.com/cometbft/cometbft v0.38.6
 	github.com/urfave/cli/v2 v2.27.2
 	go.uber.org/zap v1.26.0
+	google.golang.org/protobuf v1.33.0
 )
 
 require (
@@ -155,7 +156,6 @@ require (
 	google.golang.org/genproto/googleapis/api v0.0.0-20240123012728-ef4313101c80 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240123012728-ef4313101c80 // indirect
 	google.golang.org/grpc v1.62.0 // indirect
-	google.golang.org/protobuf v1.33.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -0,0 +1,251 @@
+package internal
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cometbft/cometbft/rpc/core/types"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Codespaces of the errors returned for the revoked grants
+const (
+	codespaceAuthz    = "authz"
+	codespaceFeegrant = "feegrant"
+)
+
+// RunAuthzTests checks the authz and feegrant modules: user2 sends the tokens of user1
+// with a send authorization, pays its fees from the allowance of user1 and executes
+// the nameservice contract on behalf of user1 with a generic authorization.
+// Each grant is revoked and the next use of it must fail.
+func RunAuthzTests(c *rpchttp.HTTP, log logging.Logger, fixtures *Fixtures, gasReport *GasReport) {
+	err := fixtures.Require(
+		[]string{"user1", "user2"},
+		[]string{
+			"authz_grant_send", "authz_exec_send", "authz_revoke_send", "authz_exec_send_revoked",
+			"feegrant_grant", "feegrant_send", "feegrant_revoke", "feegrant_send_revoked",
+			"authz_grant_execute", "authz_exec_register", "authz_revoke_execute", "authz_exec_register_revoked",
+		},
+		[]string{"balances_user1", "balances_user2", "resolve_authz", "authz_grants_user2", "feegrant_allowances_user2"},
+	)
+	if err != nil {
+		log.Fatal("invalid authz fixtures", zap.Error(err))
+		return
+	}
+
+	addressU1 := fixtures.Address("user1")
+	queryU1 := fixtures.Query("balances_user1")
+	addressU2 := fixtures.Address("user2")
+	queryU2 := fixtures.Query("balances_user2")
+
+	balanceU1 := GetBalance(c, log, queryU1, wasmDenom)
+	balanceU2 := GetBalance(c, log, queryU2, wasmDenom)
+
+	// send authorization
+	log.Info("Granting user2 a send authorization of user1")
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("authz_grant_send")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("authz_grant_send"), balanceU1, 0)
+	CheckResponseCount(c, log, "/cosmos.authz.v1beta1.Query/GranteeGrants", fixtures.Query("authz_grants_user2"), 1)
+
+	log.Info("Sending 1000000 tokens of user1 by user2")
+	resExecSend, err := broadcastAndWait(c, log, fixtures.Tx("authz_exec_send"))
+	if err != nil {
+		return
+	}
+	gasReport.Record(c, log, "/cosmos.authz.v1beta1.MsgExec", resExecSend.Hash)
+	balanceU2 = CheckFeeDeduction(c, log, addressU2, queryU2, fixtures.Tx("authz_exec_send"), balanceU2, -1000000)
+	balanceU1 = CheckBalance(c, log, addressU1, queryU1, balanceU1-1000000)
+
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("authz_revoke_send")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("authz_revoke_send"), balanceU1, 0)
+	CheckResponseCount(c, log, "/cosmos.authz.v1beta1.Query/GranteeGrants", fixtures.Query("authz_grants_user2"), 0)
+
+	log.Info("Sending tokens of user1 with the revoked authorization")
+	res, err := BroadCastTxAsync(c, log, fixtures.Tx("authz_exec_send_revoked"))
+	if err != nil {
+		return
+	}
+	if _, err := WaitTxFailed(c, log, res.Hash, codespaceAuthz); err != nil {
+		return
+	}
+	balanceU2 = CheckFeeDeduction(c, log, addressU2, queryU2, fixtures.Tx("authz_exec_send_revoked"), balanceU2, 0)
+	balanceU1 = CheckBalance(c, log, addressU1, queryU1, balanceU1)
+
+	// fee allowance
+	log.Info("Granting user2 a fee allowance of user1")
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("feegrant_grant")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("feegrant_grant"), balanceU1, 0)
+	CheckResponseCount(c, log, "/cosmos.feegrant.v1beta1.Query/Allowances", fixtures.Query("feegrant_allowances_user2"), 1)
+
+	log.Info("Sending 1000 tokens from user2 with the fee paid by user1")
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("feegrant_send")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("feegrant_send"), balanceU1, -1000)
+	balanceU2 = CheckBalance(c, log, addressU2, queryU2, balanceU2-1000)
+
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("feegrant_revoke")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("feegrant_revoke"), balanceU1, 0)
+	CheckResponseCount(c, log, "/cosmos.feegrant.v1beta1.Query/Allowances", fixtures.Query("feegrant_allowances_user2"), 0)
+
+	log.Info("Sending tokens from user2 with the revoked fee allowance")
+	if err := BroadcastTxRejected(c, log, fixtures.Tx("feegrant_send_revoked"), codespaceFeegrant); err != nil {
+		return
+	}
+	balanceU1 = CheckBalance(c, log, addressU1, queryU1, balanceU1)
+	balanceU2 = CheckBalance(c, log, addressU2, queryU2, balanceU2)
+
+	// generic authorization of the contract execution
+	log.Info("Granting user2 a generic authorization to execute contracts of user1")
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("authz_grant_execute")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("authz_grant_execute"), balanceU1, 0)
+	CheckResponseCount(c, log, "/cosmos.authz.v1beta1.Query/GranteeGrants", fixtures.Query("authz_grants_user2"), 1)
+
+	log.Info("Registering a name for user1 by user2")
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("authz_exec_register")); err != nil {
+		return
+	}
+	balanceU2 = CheckFeeDeduction(c, log, addressU2, queryU2, fixtures.Tx("authz_exec_register"), balanceU2, 0)
+	balanceU1 = CheckBalance(c, log, addressU1, queryU1, balanceU1-100000)
+	CheckNameOwner(c, log, fixtures.Query("resolve_authz"), addressU1)
+
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("authz_revoke_execute")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("authz_revoke_execute"), balanceU1, 0)
+	CheckResponseCount(c, log, "/cosmos.authz.v1beta1.Query/GranteeGrants", fixtures.Query("authz_grants_user2"), 0)
+
+	log.Info("Registering a name for user1 with the revoked authorization")
+	res, err = BroadCastTxAsync(c, log, fixtures.Tx("authz_exec_register_revoked"))
+	if err != nil {
+		return
+	}
+	if _, err := WaitTxFailed(c, log, res.Hash, codespaceAuthz); err != nil {
+		return
+	}
+	CheckFeeDeduction(c, log, addressU2, queryU2, fixtures.Tx("authz_exec_register_revoked"), balanceU2, 0)
+	CheckBalance(c, log, addressU1, queryU1, balanceU1)
+
+	log.Info("Success! authz and feegrant checks passed")
+}
+
+// broadcastAndWait broadcasts the transaction and waits until it is committed
+func broadcastAndWait(c *rpchttp.HTTP, log logging.Logger, txHex string) (*coretypes.ResultTx, error) {
+	res, err := BroadCastTxAsync(c, log, txHex)
+	if err != nil {
+		return nil, err
+	}
+	return WaitTxCommitted(c, log, res.Hash)
+}
+
+// CheckBalance checks the balance of the address equals the expected one, returns the balance
+func CheckBalance(c *rpchttp.HTTP, log logging.Logger, address, querystring string, expected int64) int64 {
+	balance := GetBalance(c, log, querystring, wasmDenom)
+	if balance != expected {
+		log.Fatal("unexpected balance",
+			zap.String("address", address),
+			zap.Int64("expected", expected),
+			zap.Int64("balance", balance),
+		)
+		return balance
+	}
+
+	log.Info("balance check success", zap.String("address", address), zap.Int64("balance", balance))
+	return balance
+}
+
+// CheckResponseCount checks the number of the entries returned by a list query,
+// e.g. the grants or the allowances, the responses list them in the field 1
+func CheckResponseCount(c *rpchttp.HTTP, log logging.Logger, path, querystring string, expected int) {
+	value, ok := abciQuery(c, log, path, querystring)
+	if !ok {
+		return
+	}
+
+	count := 0
+	err := walkMessage(value, func(num protowire.Number, _ []byte) error {
+		if num == 1 {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal("error decoding response", zap.String("path", path), zap.Error(err))
+		return
+	}
+	if count != expected {
+		log.Fatal("unexpected number of entries", zap.String("path", path), zap.Int("expected", expected), zap.Int("count", count))
+		return
+	}
+
+	log.Info("entry count check success", zap.String("path", path), zap.Int("count", count))
+}
+
+// CheckNameOwner checks the nameservice resolves the name to the owner
+func CheckNameOwner(c *rpchttp.HTTP, log logging.Logger, querystring, owner string) {
+	value, ok := abciQuery(c, log, "/cosmwasm.wasm.v1.Query/SmartContractState", querystring)
+	if !ok {
+		return
+	}
+
+	// QuerySmartContractStateResponse: 1 - data
+	var data []byte
+	err := walkMessage(value, func(num protowire.Number, v []byte) error {
+		if num == 1 {
+			data = v
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal("error decoding contract state", zap.Error(err))
+		return
+	}
+
+	var record struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		log.Fatal("error decoding resolve record", zap.String("data", string(data)), zap.Error(err))
+		return
+	}
+	if record.Address != owner {
+		log.Fatal("unexpected name owner", zap.String("expected", owner), zap.String("owner", record.Address))
+		return
+	}
+
+	log.Info("name owner check success", zap.String("owner", owner))
+}
+
+// abciQuery performs the query with the hex encoded request, returns the response value
+func abciQuery(c *rpchttp.HTTP, log logging.Logger, path, querystring string) ([]byte, bool) {
+	reqBytes, err := hex.DecodeString(querystring)
+	if err != nil {
+		log.Fatal("error decoding hex", zap.Error(err))
+		return nil, false
+	}
+
+	res, err := c.ABCIQuery(context.Background(), path, reqBytes)
+	if err != nil {
+		log.Fatal("ABCIQuery failed", zap.String("path", path), zap.Error(err))
+		return nil, false
+	}
+	if res.Response.IsErr() {
+		log.Fatal("ABCIQuery failed", zap.String("path", path), zap.String("response", res.Response.Log))
+		return nil, false
+	}
+	return res.Response.Value, true
+}
@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+)
+
+// RunDeployTests deploys the manifest with tools/deploy: the contracts are uploaded and instantiated
+// by chainclient in the order of their dependencies. The manifest path is relative to the tool directory,
+// the deployment state is written to a temporary directory so every run deploys the contracts to the new chain.
+func RunDeployTests(toolDir, manifest, rpc, chainID string, log logging.Logger) error {
+	stateDir, err := os.MkdirTemp("", "landslide-deploy")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stateDir)
+
+	log.Info("running deploy tests",
+		zap.String("dir", toolDir),
+		zap.String("manifest", manifest),
+		zap.String("chainID", chainID),
+	)
+	err = runGoTool(toolDir,
+		"-manifest", manifest,
+		"-rpc", rpc,
+		"-chain-id", chainID,
+		"-state", filepath.Join(stateDir, "deploy.state.json"),
+	)
+	if err != nil {
+		return fmt.Errorf("deploy tests failed: %w", err)
+	}
+
+	log.Info("Success! Deploy tests passed")
+	return nil
+}
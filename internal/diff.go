@@ -0,0 +1,451 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReferenceRPCPort = 26757
+	defaultReferenceP2PPort = 26756
+	referenceStartTimeout   = 30 * time.Second
+)
+
+// AllowedDiff is a known difference between the Landslide and the reference
+// CometBFT responses, which is reported but does not fail the run.
+type AllowedDiff struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// defaultAllowedDiffs are the differences LandslideVM has by design,
+// since the consensus is provided by Avalanche instead of CometBFT.
+var defaultAllowedDiffs = []AllowedDiff{
+	{Method: "block", Path: "block.header.proposer_address", Reason: "no CometBFT proposer, blocks are built by avalanche"},
+	{Method: "commit", Path: "signed_header.header.proposer_address", Reason: "no CometBFT proposer, blocks are built by avalanche"},
+	{Method: "block", Path: "block.last_commit.signatures[].signature", Reason: "commits are not signed by CometBFT validators"},
+	{Method: "commit", Path: "signed_header.commit.signatures[].signature", Reason: "commits are not signed by CometBFT validators"},
+}
+
+// Diff is a single difference between the Landslide and the reference response.
+type Diff struct {
+	Method    string
+	Path      string
+	Kind      string
+	Reference string
+	Landslide string
+}
+
+// DiffReport collects the differences found during the differential run.
+type DiffReport struct {
+	allowed map[string]string
+	Diffs   []Diff
+	Allowed []Diff
+}
+
+// NewDiffReport creates a report with the default allowlist
+// extended by the given entries.
+func NewDiffReport(extra []AllowedDiff) *DiffReport {
+	r := &DiffReport{allowed: make(map[string]string)}
+	for _, a := range append(defaultAllowedDiffs, extra...) {
+		r.allowed[a.Method+":"+a.Path] = a.Reason
+	}
+	return r
+}
+
+// LoadAllowedDiffs reads an allowlist from a json file.
+func LoadAllowedDiffs(path string) ([]AllowedDiff, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var allowed []AllowedDiff
+	if err := json.Unmarshal(bz, &allowed); err != nil {
+		return nil, fmt.Errorf("failed to parse allowlist %s: %w", path, err)
+	}
+	return allowed, nil
+}
+
+func (r *DiffReport) add(d Diff) {
+	if _, ok := r.allowed[d.Method+":"+d.Path]; ok {
+		r.Allowed = append(r.Allowed, d)
+		return
+	}
+	r.Diffs = append(r.Diffs, d)
+}
+
+// Compare walks both json documents and records shape and semantic differences.
+// Values are compared only for the paths listed in mustMatch, since hashes,
+// heights and timestamps naturally differ between two chains.
+func (r *DiffReport) Compare(method string, ref, got json.RawMessage, mustMatch []string) error {
+	var refV, gotV interface{}
+	if err := json.Unmarshal(ref, &refV); err != nil {
+		return fmt.Errorf("failed to decode reference %s response: %w", method, err)
+	}
+	if err := json.Unmarshal(got, &gotV); err != nil {
+		return fmt.Errorf("failed to decode landslide %s response: %w", method, err)
+	}
+
+	r.compareValue(method, "", refV, gotV)
+
+	for _, path := range mustMatch {
+		refField, _ := lookupPath(refV, path)
+		gotField, _ := lookupPath(gotV, path)
+		refBz, _ := json.Marshal(refField)
+		gotBz, _ := json.Marshal(gotField)
+		if !bytes.Equal(refBz, gotBz) {
+			r.add(Diff{Method: method, Path: path, Kind: "value mismatch", Reference: string(refBz), Landslide: string(gotBz)})
+		}
+	}
+	return nil
+}
+
+func (r *DiffReport) compareValue(method, path string, ref, got interface{}) {
+	if jsonKind(ref) != jsonKind(got) {
+		// null and empty arrays are encoded differently by some endpoints
+		if !(isEmpty(ref) && isEmpty(got)) {
+			r.add(Diff{Method: method, Path: path, Kind: "type mismatch", Reference: jsonKind(ref), Landslide: jsonKind(got)})
+		}
+		return
+	}
+
+	switch refV := ref.(type) {
+	case map[string]interface{}:
+		gotV := got.(map[string]interface{})
+		for _, k := range sortedKeys(refV) {
+			if _, ok := gotV[k]; !ok {
+				r.add(Diff{Method: method, Path: joinPath(path, k), Kind: "missing field", Reference: "present", Landslide: "absent"})
+				continue
+			}
+			r.compareValue(method, joinPath(path, k), refV[k], gotV[k])
+		}
+		for _, k := range sortedKeys(gotV) {
+			if _, ok := refV[k]; !ok {
+				r.add(Diff{Method: method, Path: joinPath(path, k), Kind: "extra field", Reference: "absent", Landslide: "present"})
+			}
+		}
+	case []interface{}:
+		gotV := got.([]interface{})
+		if len(refV) > 0 && len(gotV) == 0 {
+			r.add(Diff{Method: method, Path: path + "[]", Kind: "empty list", Reference: strconv.Itoa(len(refV)), Landslide: "0"})
+			return
+		}
+		// the shape of the list items is compared against the first reference item
+		if len(refV) > 0 {
+			for _, item := range gotV {
+				r.compareValue(method, path+"[]", refV[0], item)
+			}
+		}
+	case string:
+		gotV := got.(string)
+		switch {
+		case refV != "" && gotV == "":
+			r.add(Diff{Method: method, Path: path, Kind: "empty value", Reference: refV, Landslide: gotV})
+		case isZeroHex(gotV) && !isZeroHex(refV):
+			r.add(Diff{Method: method, Path: path, Kind: "all zeros", Reference: refV, Landslide: gotV})
+		}
+	}
+}
+
+// Log prints the report, returns an error if there are not allowed differences.
+func (r *DiffReport) Log(log logging.Logger) error {
+	for _, d := range r.Allowed {
+		log.Info("allowed difference",
+			zap.String("method", d.Method),
+			zap.String("path", d.Path),
+			zap.String("kind", d.Kind),
+			zap.String("reason", r.allowed[d.Method+":"+d.Path]),
+		)
+	}
+	for _, d := range r.Diffs {
+		log.Error("difference",
+			zap.String("method", d.Method),
+			zap.String("path", d.Path),
+			zap.String("kind", d.Kind),
+			zap.String("reference", d.Reference),
+			zap.String("landslide", d.Landslide),
+		)
+	}
+	if len(r.Diffs) > 0 {
+		return fmt.Errorf("found %d differences with the reference node", len(r.Diffs))
+	}
+	return nil
+}
+
+// ReferenceNode is a standalone CometBFT node running the built-in kvstore app.
+type ReferenceNode struct {
+	cmd    *exec.Cmd
+	logs   *os.File
+	RPCURL string
+}
+
+// StartReferenceNode initializes a fresh home directory and starts
+// a single validator CometBFT node with the kvstore app.
+func StartReferenceNode(binary, home string, log logging.Logger) (*ReferenceNode, error) {
+	if err := os.RemoveAll(home); err != nil {
+		return nil, err
+	}
+	if out, err := exec.Command(binary, "init", "--home", home).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("cometbft init failed: %w: %s", err, out)
+	}
+
+	logs, err := os.Create(filepath.Join(home, "node.log"))
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(binary, "start",
+		"--home", home,
+		"--proxy_app", "kvstore",
+		"--rpc.laddr", fmt.Sprintf("tcp://127.0.0.1:%d", defaultReferenceRPCPort),
+		"--p2p.laddr", fmt.Sprintf("tcp://127.0.0.1:%d", defaultReferenceP2PPort),
+	)
+	cmd.Stdout = logs
+	cmd.Stderr = logs
+	if err := cmd.Start(); err != nil {
+		logs.Close()
+		return nil, fmt.Errorf("failed to start cometbft: %w", err)
+	}
+
+	n := &ReferenceNode{
+		cmd:    cmd,
+		logs:   logs,
+		RPCURL: fmt.Sprintf("http://127.0.0.1:%d", defaultReferenceRPCPort),
+	}
+	log.Info("reference cometbft node started", zap.String("rpc", n.RPCURL), zap.String("home", home))
+
+	if err := n.awaitBlocks(); err != nil {
+		n.Stop()
+		return nil, err
+	}
+	return n, nil
+}
+
+// awaitBlocks waits until the reference node produces its first block
+func (n *ReferenceNode) awaitBlocks() error {
+	c, err := rpchttp.New(n.RPCURL, "/websocket")
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(referenceStartTimeout)
+	for time.Now().Before(deadline) {
+		s, err := c.Status(context.Background())
+		if err == nil && s.SyncInfo.LatestBlockHeight > 0 {
+			return nil
+		}
+		<-time.After(500 * time.Millisecond)
+	}
+	return errors.New("reference cometbft node did not produce blocks in time")
+}
+
+// Stop stops the reference node process
+func (n *ReferenceNode) Stop() {
+	if n.cmd.Process != nil {
+		_ = n.cmd.Process.Signal(os.Interrupt)
+		_ = n.cmd.Wait()
+	}
+	n.logs.Close()
+}
+
+// RunDiffTests replays the same kvstore transactions against landslide
+// and the reference node and compares block, commit, status and tx responses.
+func RunDiffTests(landslideAddr, referenceAddr string, allowed []AllowedDiff, log logging.Logger) error {
+	landslide, err := rpchttp.New(landslideAddr, "/websocket")
+	if err != nil {
+		return err
+	}
+	reference, err := rpchttp.New(referenceAddr, "/websocket")
+	if err != nil {
+		return err
+	}
+	<-time.After(2 * time.Second) // wait for first block to be committed
+
+	report := NewDiffReport(allowed)
+
+	compare := func(method string, refParams, gotParams map[string]interface{}, mustMatch ...string) error {
+		ref, err := rawRPCCall(referenceAddr, method, refParams)
+		if err != nil {
+			return fmt.Errorf("reference %s: %w", method, err)
+		}
+		got, err := rawRPCCall(landslideAddr, method, gotParams)
+		if err != nil {
+			return fmt.Errorf("landslide %s: %w", method, err)
+		}
+		return report.Compare(method, ref, got, mustMatch)
+	}
+
+	if err := compare("status", nil, nil); err != nil {
+		return err
+	}
+
+	for i := 0; i < 5; i++ {
+		_, _, tx := MakeTxKV()
+
+		refRes, err := reference.BroadcastTxCommit(context.Background(), tx)
+		if err != nil {
+			return fmt.Errorf("reference broadcast: %w", err)
+		}
+		gotRes, err := landslide.BroadcastTxCommit(context.Background(), tx)
+		if err != nil {
+			return fmt.Errorf("landslide broadcast: %w", err)
+		}
+		log.Info("tx committed on both nodes",
+			zap.Int64("referenceHeight", refRes.Height),
+			zap.Int64("landslideHeight", gotRes.Height),
+			zap.Stringer("hash", gotRes.Hash),
+		)
+
+		hash := map[string]interface{}{"hash": base64.StdEncoding.EncodeToString(gotRes.Hash)}
+		if err := compare("tx", hash, hash,
+			"hash", "tx", "index", "tx_result.code", "tx_result.data", "tx_result.log", "tx_result.events",
+		); err != nil {
+			return err
+		}
+
+		refHeight := map[string]interface{}{"height": strconv.FormatInt(refRes.Height, 10)}
+		gotHeight := map[string]interface{}{"height": strconv.FormatInt(gotRes.Height, 10)}
+		if err := compare("block", refHeight, gotHeight, "block.data.txs", "block.header.version"); err != nil {
+			return err
+		}
+		if err := compare("commit", refHeight, gotHeight, "canonical", "signed_header.header.version"); err != nil {
+			return err
+		}
+	}
+
+	if err := compare("status", nil, nil); err != nil {
+		return err
+	}
+
+	return report.Log(log)
+}
+
+// rawRPCCall performs a json-rpc call and returns the raw result,
+// so that the response shape is not normalized by the typed client.
+func rawRPCCall(addr, method string, params map[string]interface{}) (json.RawMessage, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	reqBz, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.Post(addr, "application/json", bytes.NewReader(reqBz))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+			Data    string `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("%s: %s", res.Error.Message, res.Error.Data)
+	}
+	return res.Result, nil
+}
+
+func lookupPath(v interface{}, path string) (interface{}, bool) {
+	for _, k := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func isZeroHex(s string) bool {
+	if len(s) < 8 {
+		return false
+	}
+	return strings.Trim(s, "0") == ""
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiffReportCompare(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		method    string
+		ref, got  string
+		mustMatch []string
+		extra     []AllowedDiff
+		diffs     []string
+		allowed   []string
+	}{
+		{
+			name:   "same shape, different values",
+			method: "status",
+			ref:    `{"node_info":{"network":"a"},"sync_info":{"latest_block_height":"10"}}`,
+			got:    `{"node_info":{"network":"b"},"sync_info":{"latest_block_height":"20"}}`,
+		},
+		{
+			name:      "value mismatch of a must match path",
+			method:    "abci_info",
+			ref:       `{"response":{"data":"kvstore"}}`,
+			got:       `{"response":{"data":"landslide"}}`,
+			mustMatch: []string{"response.data"},
+			diffs:     []string{"response.data:value mismatch"},
+		},
+		{
+			name:   "missing, extra and type mismatch",
+			method: "block",
+			ref:    `{"block":{"header":{"height":"1","time":"t"}}}`,
+			got:    `{"block":{"header":{"height":1,"extra":"x"}}}`,
+			diffs: []string{
+				"block.header.height:type mismatch",
+				"block.header.time:missing field",
+				"block.header.extra:extra field",
+			},
+		},
+		{
+			name:   "null and empty list are equal",
+			method: "block",
+			ref:    `{"block":{"evidence":{"evidence":[]}}}`,
+			got:    `{"block":{"evidence":{"evidence":null}}}`,
+		},
+		{
+			name:   "empty list and empty value",
+			method: "validators",
+			ref:    `{"validators":[{"address":"AB"}],"total":"1"}`,
+			got:    `{"validators":[],"total":""}`,
+			diffs:  []string{"total:empty value", "validators[]:empty list"},
+		},
+		{
+			name:    "all zeros proposer is allowed by default",
+			method:  "block",
+			ref:     `{"block":{"header":{"proposer_address":"5A1B2C3D4E5F"}}}`,
+			got:     `{"block":{"header":{"proposer_address":"000000000000"}}}`,
+			allowed: []string{"block.header.proposer_address:all zeros"},
+		},
+		{
+			name:   "allowlist is per method",
+			method: "header",
+			ref:    `{"header":{"proposer_address":"5A1B2C3D4E5F"}}`,
+			got:    `{"header":{"proposer_address":"000000000000"}}`,
+			diffs:  []string{"header.proposer_address:all zeros"},
+		},
+		{
+			name:    "extra allowlist entry",
+			method:  "header",
+			ref:     `{"header":{"proposer_address":"5A1B2C3D4E5F"}}`,
+			got:     `{"header":{"proposer_address":"000000000000"}}`,
+			extra:   []AllowedDiff{{Method: "header", Path: "header.proposer_address", Reason: "no proposer"}},
+			allowed: []string{"header.proposer_address:all zeros"},
+		},
+		{
+			name:    "list item paths match the allowlist",
+			method:  "commit",
+			ref:     `{"signed_header":{"commit":{"signatures":[{"signature":"c2ln"}]}}}`,
+			got:     `{"signed_header":{"commit":{"signatures":[{"signature":""}]}}}`,
+			allowed: []string{"signed_header.commit.signatures[].signature:empty value"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewDiffReport(tc.extra)
+			if err := r.Compare(tc.method, json.RawMessage(tc.ref), json.RawMessage(tc.got), tc.mustMatch); err != nil {
+				t.Fatal(err)
+			}
+			checkDiffs(t, "differences", r.Diffs, tc.diffs)
+			checkDiffs(t, "allowed differences", r.Allowed, tc.allowed)
+		})
+	}
+
+	if err := NewDiffReport(nil).Compare("status", json.RawMessage(`{`), json.RawMessage(`{}`), nil); err == nil {
+		t.Error("expected error for invalid reference json")
+	}
+}
+
+// checkDiffs compares the path and kind of the differences in order
+func checkDiffs(t *testing.T, name string, diffs []Diff, want []string) {
+	t.Helper()
+	got := make([]string, len(diffs))
+	for i, d := range diffs {
+		got[i] = d.Path + ":" + d.Kind
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%s = %v, want %v", name, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s = %v, want %v", name, got, want)
+		}
+	}
+}
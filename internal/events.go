@@ -0,0 +1,393 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanche-network-runner/network"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+const (
+	eventsSubscriber      = "landslide-runner"
+	eventsChannelCapacity = 100
+	eventsDeliveryTimeout = 15 * time.Second
+	// the websocket client reconnects with an exponential backoff, so it may reconnect
+	// as late as twice the restart time
+	eventsReconnectTimeout = 3 * time.Minute
+
+	queryNewBlock       = "tm.event='NewBlock'"
+	queryNewBlockHeader = "tm.event='NewBlockHeader'"
+	queryTx             = "tm.event='Tx'"
+)
+
+// TxEvents collects the tx events delivered by a single subscription
+type TxEvents struct {
+	mu     sync.Mutex
+	events []types.EventDataTx
+}
+
+// Events returns a copy of the collected events
+func (e *TxEvents) Events() []types.EventDataTx {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]types.EventDataTx(nil), e.events...)
+}
+
+func (e *TxEvents) collect(out <-chan coretypes.ResultEvent, done <-chan struct{}) {
+	for {
+		select {
+		case ev, ok := <-out:
+			// the client closes the channel when it stops
+			if !ok {
+				return
+			}
+			if data, ok := ev.Data.(types.EventDataTx); ok {
+				e.mu.Lock()
+				e.events = append(e.events, data)
+				e.mu.Unlock()
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// SubscribeTxEvents subscribes to the tx events matching the query.
+// The client is started if it is not running yet.
+func SubscribeTxEvents(c *rpchttp.HTTP, log logging.Logger, query string, done <-chan struct{}) *TxEvents {
+	if !c.IsRunning() {
+		if err := c.Start(); err != nil {
+			log.Fatal("error starting websocket client", zap.Error(err))
+			return nil
+		}
+	}
+
+	out, err := c.Subscribe(context.Background(), eventsSubscriber, query, eventsChannelCapacity)
+	if err != nil {
+		log.Fatal("error subscribing", zap.String("query", query), zap.Error(err))
+		return nil
+	}
+
+	events := &TxEvents{}
+	go events.collect(out, done)
+	return events
+}
+
+// CheckTxDelivered waits until the tx is delivered and checks it was delivered exactly once
+func CheckTxDelivered(log logging.Logger, events *TxEvents, query string, hash []byte) {
+	deadline := time.Now().Add(eventsDeliveryTimeout)
+	for {
+		delivered := 0
+		for _, ev := range events.Events() {
+			if string(types.Tx(ev.Tx).Hash()) == string(hash) {
+				delivered++
+			}
+		}
+
+		switch {
+		case delivered == 1:
+			log.Info("tx event delivered", zap.String("query", query), zap.String("hash", fmt.Sprintf("%X", hash)))
+			return
+		case delivered > 1:
+			log.Fatal("tx event delivered more than once", zap.String("query", query), zap.Int("times", delivered))
+			return
+		case time.Now().After(deadline):
+			log.Fatal("tx event was not delivered", zap.String("query", query), zap.String("hash", fmt.Sprintf("%X", hash)))
+			return
+		}
+		<-time.After(500 * time.Millisecond)
+	}
+}
+
+// nodeEvents holds the event streams subscribed on a single node
+type nodeEvents struct {
+	name string
+	c    *rpchttp.HTTP
+
+	mu            sync.Mutex
+	blockHeights  []int64
+	headerHeights []int64
+	txs           *TxEvents
+	keyTxs        *TxEvents
+}
+
+func subscribeNode(name, rpcAddr, keyQuery string, log logging.Logger, done <-chan struct{}) *nodeEvents {
+	c, err := rpchttp.New(rpcAddr, "/websocket")
+	if err != nil {
+		log.Fatal("error creating client", zap.Error(err))
+		return nil
+	}
+
+	n := &nodeEvents{
+		name:   name,
+		c:      c,
+		txs:    SubscribeTxEvents(c, log, queryTx, done),
+		keyTxs: SubscribeTxEvents(c, log, keyQuery, done),
+	}
+
+	blocks, err := c.Subscribe(context.Background(), eventsSubscriber, queryNewBlock, eventsChannelCapacity)
+	if err != nil {
+		log.Fatal("error subscribing", zap.String("query", queryNewBlock), zap.Error(err))
+		return nil
+	}
+	headers, err := c.Subscribe(context.Background(), eventsSubscriber, queryNewBlockHeader, eventsChannelCapacity)
+	if err != nil {
+		log.Fatal("error subscribing", zap.String("query", queryNewBlockHeader), zap.Error(err))
+		return nil
+	}
+
+	go func() {
+		// a closed channel is set to nil, so it is not selected anymore
+		for blocks != nil || headers != nil {
+			select {
+			case ev, ok := <-blocks:
+				if !ok {
+					blocks = nil
+					continue
+				}
+				if data, ok := ev.Data.(types.EventDataNewBlock); ok {
+					n.mu.Lock()
+					n.blockHeights = append(n.blockHeights, data.Block.Height)
+					n.mu.Unlock()
+				}
+			case ev, ok := <-headers:
+				if !ok {
+					headers = nil
+					continue
+				}
+				if data, ok := ev.Data.(types.EventDataNewBlockHeader); ok {
+					n.mu.Lock()
+					n.headerHeights = append(n.headerHeights, data.Header.Height)
+					n.mu.Unlock()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	log.Info("subscribed to node events", zap.String("node", name), zap.String("rpc", rpcAddr))
+	return n
+}
+
+// RunEventTests subscribes to block and tx events on every node, drives kvstore
+// transactions and checks every committed block and tx is delivered exactly once
+// and in order. The last node is restarted in the middle of the run, its subscriptions
+// must come back and deliver the txs committed after the reconnect.
+func RunEventTests(nw network.Network, rpcAddrs map[string]string, log logging.Logger, num int) {
+	nodeNames := make([]string, 0, len(rpcAddrs))
+	for name := range rpcAddrs {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+
+	type KV struct {
+		k, v, tx []byte
+	}
+	kvs := make([]KV, num)
+	for i := range kvs {
+		k, v, tx := MakeTxKV()
+		kvs[i] = KV{k, v, tx}
+	}
+	firstKey := kvs[0].k
+	keyQuery := fmt.Sprintf("%s AND app.key='%s'", queryTx, firstKey)
+
+	done := make(chan struct{})
+	nodes := make([]*nodeEvents, len(nodeNames))
+	for i, name := range nodeNames {
+		nodes[i] = subscribeNode(name, rpcAddrs[name], keyQuery, log, done)
+	}
+
+	c := nodes[0].c
+	restarted := nodes[len(nodes)-1]
+	var (
+		committed       []committedTx
+		reconnectHeight int64
+	)
+	broadcast := func(tx []byte) bool {
+		res, err := c.BroadcastTxCommit(context.Background(), tx)
+		if err != nil {
+			log.Fatal("BroadcastTxCommit error", zap.Error(err))
+			return false
+		}
+		if res.CheckTx.IsErr() || res.TxResult.IsErr() {
+			log.Fatal("BroadcastTxCommit transaction failed")
+			return false
+		}
+		committed = append(committed, committedTx{hash: types.Tx(tx).Hash(), height: res.Height})
+		return true
+	}
+
+	for i, kv := range kvs {
+		if !broadcast(kv.tx) {
+			return
+		}
+
+		if i == num/2 && restarted != nodes[0] {
+			restartHeight := committed[len(committed)-1].height
+			log.Info("restarting node in the middle of subscription", zap.String("node", restarted.name))
+			if err := nw.RestartNode(context.Background(), restarted.name, "", "", "", nil, nil, nil); err != nil {
+				log.Fatal("error restarting node", zap.Error(err))
+				return
+			}
+			if err := Await(nw, log, 2*time.Minute); err != nil {
+				log.Fatal("error waiting for restarted node", zap.Error(err))
+				return
+			}
+
+			// blocks are built only for txs, so txs are committed
+			// until the restarted node delivers a new block
+			deadline := time.Now().Add(eventsReconnectTimeout)
+			for {
+				if reconnectHeight = restarted.firstBlockAfter(restartHeight); reconnectHeight != 0 {
+					break
+				}
+				if time.Now().After(deadline) {
+					log.Fatal("subscriptions of the restarted node did not come back", zap.String("node", restarted.name))
+					return
+				}
+				_, _, tx := MakeTxKV()
+				if !broadcast(tx) {
+					return
+				}
+				<-time.After(2 * time.Second)
+			}
+			log.Info("restarted node resubscribed", zap.String("node", restarted.name), zap.Int64("height", reconnectHeight))
+		}
+	}
+
+	// give the nodes time to deliver the events of the last block
+	<-time.After(eventsDeliveryTimeout)
+	close(done)
+
+	for _, n := range nodes {
+		gapAllowed := n == restarted && reconnectHeight != 0
+
+		n.mu.Lock()
+		checkHeights(log, n.name, queryNewBlock, n.blockHeights, gapAllowed)
+		checkHeights(log, n.name, queryNewBlockHeader, n.headerHeights, gapAllowed)
+		n.mu.Unlock()
+
+		txs := n.txs.Events()
+		delivered := make(map[string]int, len(txs))
+		for i, ev := range txs {
+			delivered[string(types.Tx(ev.Tx).Hash())]++
+
+			if i > 0 && (txs[i-1].Height > ev.Height || txs[i-1].Height == ev.Height && txs[i-1].Index >= ev.Index) {
+				log.Fatal("tx events delivered out of order", zap.String("node", n.name), zap.Int64("height", ev.Height))
+				return
+			}
+		}
+
+		for _, tx := range committed {
+			times := delivered[string(tx.hash)]
+			// the txs committed while the restarted node was reconnecting may be missed
+			if times == 0 && gapAllowed && tx.height < reconnectHeight {
+				continue
+			}
+			if times != 1 {
+				log.Fatal("tx event not delivered exactly once",
+					zap.String("node", n.name),
+					zap.String("hash", fmt.Sprintf("%X", tx.hash)),
+					zap.Int64("height", tx.height),
+					zap.Int("times", times),
+				)
+				return
+			}
+		}
+
+		for _, ev := range txs {
+			if !hasAttribute(ev, "app", "key", "") {
+				log.Fatal("tx event has no app.key attribute", zap.String("node", n.name), zap.Int64("height", ev.Height))
+				return
+			}
+		}
+
+		keyTxs := n.keyTxs.Events()
+		if len(keyTxs) != 1 || !hasAttribute(keyTxs[0], "app", "key", string(firstKey)) {
+			log.Fatal("custom query events mismatch",
+				zap.String("node", n.name),
+				zap.String("query", keyQuery),
+				zap.Int("delivered", len(keyTxs)),
+			)
+			return
+		}
+
+		if err := n.c.Stop(); err != nil {
+			log.Error("error stopping websocket client", zap.Error(err))
+		}
+
+		log.Info("node events success", zap.String("node", n.name), zap.Int("txs", len(txs)))
+	}
+}
+
+// committedTx is a tx committed by the event tests
+type committedTx struct {
+	hash   []byte
+	height int64
+}
+
+// firstBlockAfter returns the first delivered block height above the height, 0 if there is none
+func (n *nodeEvents) firstBlockAfter(height int64) int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, h := range n.blockHeights {
+		if h > height {
+			return h
+		}
+	}
+	return 0
+}
+
+// checkHeights checks that heights are strictly increasing without gaps.
+// A single gap is allowed for the node which was restarted.
+func checkHeights(log logging.Logger, node, query string, heights []int64, gapAllowed bool) {
+	if len(heights) == 0 {
+		log.Fatal("no events delivered", zap.String("node", node), zap.String("query", query))
+		return
+	}
+
+	gaps := 0
+	for i := 1; i < len(heights); i++ {
+		switch {
+		case heights[i] <= heights[i-1]:
+			log.Fatal("events delivered out of order or duplicated",
+				zap.String("node", node),
+				zap.String("query", query),
+				zap.Int64("prev", heights[i-1]),
+				zap.Int64("height", heights[i]),
+			)
+			return
+		case heights[i] != heights[i-1]+1:
+			gaps++
+		}
+	}
+
+	if gaps > 1 || gaps == 1 && !gapAllowed {
+		log.Fatal("events missing", zap.String("node", node), zap.String("query", query), zap.Int("gaps", gaps))
+	}
+}
+
+// hasAttribute checks the tx result has the event attribute,
+// if value is empty only the presence of the attribute is checked
+func hasAttribute(ev types.EventDataTx, eventType, key, value string) bool {
+	for _, e := range ev.Result.Events {
+		if e.Type != eventType {
+			continue
+		}
+		for _, attr := range e.Attributes {
+			if attr.Key == key && (value == "" || attr.Value == value) {
+				return true
+			}
+		}
+	}
+	return false
+}
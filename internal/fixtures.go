@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// Fixtures - payloads signed offline by tools/payload_gen from a scenario file
+	Fixtures struct {
+		Scenario  string                    `json:"scenario"`
+		ChainID   string                    `json:"chain_id"`
+		Accounts  map[string]AccountFixture `json:"accounts"`
+		Contracts map[string]string         `json:"contracts"`
+		Txs       map[string]TxFixture      `json:"txs"`
+		Queries   map[string]QueryFixture   `json:"queries"`
+	}
+
+	// AccountFixture - address and account number of the signer
+	AccountFixture struct {
+		Address string `json:"address"`
+		Number  uint64 `json:"number"`
+	}
+
+	// TxFixture - hex encoded signed transaction, the genesis transactions have the signed JSON too
+	TxFixture struct {
+		Signer   string          `json:"signer"`
+		Sequence uint64          `json:"sequence"`
+		MsgTypes []string        `json:"msg_types"`
+		Hex      string          `json:"hex"`
+		JSON     json.RawMessage `json:"json"`
+	}
+
+	// QueryFixture - hex encoded ABCI query request
+	QueryFixture struct {
+		Path string `json:"path"`
+		Hex  string `json:"hex"`
+	}
+)
+
+// LoadFixtures decodes the JSON fixture bundle
+func LoadFixtures(data []byte) (*Fixtures, error) {
+	f := &Fixtures{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("error decoding fixtures: %w", err)
+	}
+	return f, nil
+}
+
+// Require checks the bundle has the accounts, transactions and queries the tests use
+func (f *Fixtures) Require(accounts, txs, queries []string) error {
+	for _, name := range accounts {
+		if _, ok := f.Accounts[name]; !ok {
+			return fmt.Errorf("fixtures %s: account %s not found", f.Scenario, name)
+		}
+	}
+	for _, name := range txs {
+		if _, ok := f.Txs[name]; !ok {
+			return fmt.Errorf("fixtures %s: transaction %s not found", f.Scenario, name)
+		}
+	}
+	for _, name := range queries {
+		if _, ok := f.Queries[name]; !ok {
+			return fmt.Errorf("fixtures %s: query %s not found", f.Scenario, name)
+		}
+	}
+	return nil
+}
+
+// Address returns the address of the account
+func (f *Fixtures) Address(name string) string {
+	return f.Accounts[name].Address
+}
+
+// Contract returns the address of the contract
+func (f *Fixtures) Contract(name string) string {
+	return f.Contracts[name]
+}
+
+// Tx returns the hex encoded transaction
+func (f *Fixtures) Tx(name string) string {
+	return f.Txs[name].Hex
+}
+
+// Query returns the hex encoded query request
+func (f *Fixtures) Query(name string) string {
+	return f.Queries[name].Hex
+}
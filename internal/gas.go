@@ -0,0 +1,346 @@
+package internal
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	wasmDenom = "stake"
+
+	// codeOutOfGas is the cosmos-sdk ErrOutOfGas code
+	codeOutOfGas = 11
+)
+
+// GasEntry is the gas consumed by a single committed transaction
+type GasEntry struct {
+	MsgType   string
+	GasWanted int64
+	GasUsed   int64
+	Fee       int64
+}
+
+// GasReport collects the gas used per message type
+type GasReport struct {
+	entries []GasEntry
+}
+
+// Record fetches the committed transaction and records its gas usage.
+// It fails if the transaction ran out of gas or used more gas than the limit set in the tx.
+func (r *GasReport) Record(c *rpchttp.HTTP, log logging.Logger, msgType string, hash []byte) {
+	res, err := c.Tx(context.Background(), hash, false)
+	if err != nil {
+		log.Fatal("error getting transaction", zap.Error(err))
+		return
+	}
+
+	fee, gasLimit, err := DecodeTxFee(res.Tx)
+	if err != nil {
+		log.Fatal("error decoding tx fee", zap.Error(err))
+		return
+	}
+
+	if res.TxResult.Code == codeOutOfGas && res.TxResult.Codespace == "sdk" {
+		log.Fatal("transaction ran out of gas",
+			zap.String("msgType", msgType),
+			zap.Int64("gasWanted", res.TxResult.GasWanted),
+			zap.Int64("gasUsed", res.TxResult.GasUsed),
+		)
+		return
+	}
+	if res.TxResult.Code != 0 {
+		log.Fatal("transaction failed", zap.String("msgType", msgType), zap.String("log", res.TxResult.Log))
+		return
+	}
+	if uint64(res.TxResult.GasWanted) != gasLimit {
+		log.Fatal("gas wanted does not match tx gas limit",
+			zap.String("msgType", msgType),
+			zap.Int64("gasWanted", res.TxResult.GasWanted),
+			zap.Uint64("gasLimit", gasLimit),
+		)
+		return
+	}
+	if res.TxResult.GasUsed <= 0 || res.TxResult.GasUsed > res.TxResult.GasWanted {
+		log.Fatal("unexpected gas used",
+			zap.String("msgType", msgType),
+			zap.Int64("gasWanted", res.TxResult.GasWanted),
+			zap.Int64("gasUsed", res.TxResult.GasUsed),
+		)
+		return
+	}
+
+	r.entries = append(r.entries, GasEntry{
+		MsgType:   msgType,
+		GasWanted: res.TxResult.GasWanted,
+		GasUsed:   res.TxResult.GasUsed,
+		Fee:       fee,
+	})
+	log.Info("gas recorded",
+		zap.String("msgType", msgType),
+		zap.Int64("gasWanted", res.TxResult.GasWanted),
+		zap.Int64("gasUsed", res.TxResult.GasUsed),
+		zap.Int64("fee", fee),
+	)
+}
+
+// CheckOutOfGas checks the committed transaction failed with ErrOutOfGas,
+// its gas wanted is the tx gas limit and the gas used exceeds it
+func CheckOutOfGas(log logging.Logger, res *coretypes.ResultTx) error {
+	_, gasLimit, err := DecodeTxFee(res.Tx)
+	if err != nil {
+		log.Fatal("error decoding tx fee", zap.Error(err))
+		return err
+	}
+
+	if res.TxResult.Code != codeOutOfGas || res.TxResult.Codespace != "sdk" {
+		log.Fatal("transaction did not run out of gas",
+			zap.Uint32("code", res.TxResult.Code),
+			zap.String("codespace", res.TxResult.Codespace),
+			zap.String("log", res.TxResult.Log),
+		)
+		return errors.New("transaction did not run out of gas")
+	}
+	if uint64(res.TxResult.GasWanted) != gasLimit || res.TxResult.GasUsed <= res.TxResult.GasWanted {
+		log.Fatal("unexpected gas of the out of gas transaction",
+			zap.Int64("gasWanted", res.TxResult.GasWanted),
+			zap.Int64("gasUsed", res.TxResult.GasUsed),
+			zap.Uint64("gasLimit", gasLimit),
+		)
+		return errors.New("unexpected gas of the out of gas transaction")
+	}
+
+	log.Info("Success! transaction ran out of gas",
+		zap.Int64("gasWanted", res.TxResult.GasWanted),
+		zap.Int64("gasUsed", res.TxResult.GasUsed),
+	)
+	return nil
+}
+
+// Table returns the gas table sorted by message type, so reports of different
+// LandslideVM versions can be compared with diff
+func (r *GasReport) Table() string {
+	entries := append([]GasEntry(nil), r.entries...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].MsgType < entries[j].MsgType })
+
+	var sb strings.Builder
+	sb.WriteString("msg_type\tgas_wanted\tgas_used\tfee\n")
+	for _, e := range entries {
+		sb.WriteString(fmt.Sprintf("%s\t%d\t%d\t%d%s\n", e.MsgType, e.GasWanted, e.GasUsed, e.Fee, wasmDenom))
+	}
+	return sb.String()
+}
+
+// Write writes the gas table to the file, does nothing if path is empty
+func (r *GasReport) Write(log logging.Logger, path string) {
+	log.Info("gas table\n" + r.Table())
+	if path == "" {
+		return
+	}
+	if err := os.WriteFile(path, []byte(r.Table()), 0644); err != nil {
+		log.Fatal("error writing gas report", zap.Error(err))
+		return
+	}
+	log.Info("gas report written", zap.String("path", path))
+}
+
+// CheckFeeDeduction checks the balance of the fee payer decreased exactly by the tx fee
+// and the spent amount, returns the new balance
+func CheckFeeDeduction(c *rpchttp.HTTP, log logging.Logger, address, querystring, txHex string, before, spent int64) int64 {
+	txBytes, err := hex.DecodeString(txHex)
+	if err != nil {
+		log.Fatal("error decoding hex", zap.Error(err))
+		return 0
+	}
+	fee, _, err := DecodeTxFee(txBytes)
+	if err != nil {
+		log.Fatal("error decoding tx fee", zap.Error(err))
+		return 0
+	}
+
+	after := GetBalance(c, log, querystring, wasmDenom)
+	if before-after != fee+spent {
+		log.Fatal("unexpected fee deduction",
+			zap.String("address", address),
+			zap.Int64("before", before),
+			zap.Int64("after", after),
+			zap.Int64("fee", fee),
+			zap.Int64("spent", spent),
+		)
+		return after
+	}
+
+	log.Info("fee deduction success", zap.String("address", address), zap.Int64("fee", fee), zap.Int64("balance", after))
+	return after
+}
+
+// GetBalance queries the balance of an address in the given denom
+func GetBalance(c *rpchttp.HTTP, log logging.Logger, querystring, denom string) int64 {
+	reqBytes, err := hex.DecodeString(querystring)
+	if err != nil {
+		log.Fatal("error decoding hex", zap.Error(err))
+		return 0
+	}
+
+	resABCIQuery, err := c.ABCIQuery(context.Background(), "/cosmos.bank.v1beta1.Query/AllBalances", reqBytes)
+	if err != nil {
+		log.Fatal("ABCIQuery failed", zap.Error(err))
+		return 0
+	}
+	if resABCIQuery.Response.IsErr() {
+		log.Fatal("ABCIQuery failed", zap.String("response", resABCIQuery.Response.Log))
+		return 0
+	}
+
+	// QueryAllBalancesResponse: 1 - repeated Coin balances
+	var amount int64
+	err = walkMessage(resABCIQuery.Response.Value, func(num protowire.Number, v []byte) error {
+		if num != 1 {
+			return nil
+		}
+		coinDenom, coinAmount, err := decodeCoin(v)
+		if err != nil {
+			return err
+		}
+		if coinDenom == denom {
+			amount = coinAmount
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal("error decoding balances", zap.Error(err))
+		return 0
+	}
+	return amount
+}
+
+// DecodeTxFee decodes the fee amount and the gas limit from the raw cosmos-sdk tx
+func DecodeTxFee(txBytes []byte) (int64, uint64, error) {
+	var (
+		fee      int64
+		gasLimit uint64
+	)
+
+	// TxRaw: 2 - auth_info_bytes
+	err := walkMessage(txBytes, func(num protowire.Number, authInfo []byte) error {
+		if num != 2 {
+			return nil
+		}
+		// AuthInfo: 2 - fee
+		return walkMessage(authInfo, func(num protowire.Number, feeBz []byte) error {
+			if num != 2 {
+				return nil
+			}
+			// Fee: 1 - repeated Coin amount, 2 - uint64 gas_limit
+			fields := feeBz
+			for len(fields) > 0 {
+				n, typ, l := protowire.ConsumeTag(fields)
+				if l < 0 {
+					return protowire.ParseError(l)
+				}
+				fields = fields[l:]
+
+				switch {
+				case n == 1 && typ == protowire.BytesType:
+					v, l := protowire.ConsumeBytes(fields)
+					if l < 0 {
+						return protowire.ParseError(l)
+					}
+					denom, amount, err := decodeCoin(v)
+					if err != nil {
+						return err
+					}
+					if denom == wasmDenom {
+						fee = amount
+					}
+					fields = fields[l:]
+				case n == 2 && typ == protowire.VarintType:
+					v, l := protowire.ConsumeVarint(fields)
+					if l < 0 {
+						return protowire.ParseError(l)
+					}
+					gasLimit = v
+					fields = fields[l:]
+				default:
+					l := protowire.ConsumeFieldValue(n, typ, fields)
+					if l < 0 {
+						return protowire.ParseError(l)
+					}
+					fields = fields[l:]
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	if gasLimit == 0 {
+		return 0, 0, errors.New("tx has no gas limit")
+	}
+	return fee, gasLimit, nil
+}
+
+// walkMessage calls fn for every length-delimited field of the protobuf message
+func walkMessage(bz []byte, fn func(num protowire.Number, v []byte) error) error {
+	for len(bz) > 0 {
+		num, typ, l := protowire.ConsumeTag(bz)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		bz = bz[l:]
+
+		if typ != protowire.BytesType {
+			l = protowire.ConsumeFieldValue(num, typ, bz)
+			if l < 0 {
+				return protowire.ParseError(l)
+			}
+			bz = bz[l:]
+			continue
+		}
+
+		v, l := protowire.ConsumeBytes(bz)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		if err := fn(num, v); err != nil {
+			return err
+		}
+		bz = bz[l:]
+	}
+	return nil
+}
+
+// decodeCoin decodes Coin: 1 - denom, 2 - amount
+func decodeCoin(bz []byte) (string, int64, error) {
+	var denom, amount string
+	err := walkMessage(bz, func(num protowire.Number, v []byte) error {
+		switch num {
+		case 1:
+			denom = string(v)
+		case 2:
+			amount = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	n, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid coin amount %q: %w", amount, err)
+	}
+	return denom, n, nil
+}
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestDecodeTxFee(t *testing.T) {
+	// bank.MsgSend from user1 to user2 used by the wasm end-to-end tests
+	txSend := "0a8f010a8c010a1c2f636f736d6f732e62616e6b2e763162657461312e4d736753656e64126c0a2b7761736d316b6e673673716b6d306d6a7568303963777a367538366637356c6d65666c6a39683066716872122b7761736d31633477346a78646b766a337967647963646b6a7939386a7665367730643732353765716678391a100a057374616b6512073530303030303012670a4e0a460a1f2f636f736d6f732e63727970746f2e736563703235366b312e5075624b657912230a2103ca7cd136cf54b73631e5c40850fc78cca77fdcac94ea4abe2768797c8bc7b71312040a02080112150a0f0a057374616b65120631303030303010c09a0c1a40b1250c76eb38e062e141b5a5dc1badad0c21150850bebb5e3ad9e3ad109dbb5f654daa40f7c46018781f35d0a2ee302d691dc5ebf4c4ea7d0059488e537b3252"
+	txBytes, err := hex.DecodeString(txSend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fee, gasLimit, err := DecodeTxFee(txBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee != 100000 {
+		t.Errorf("fee = %d, want 100000", fee)
+	}
+	if gasLimit != 200000 {
+		t.Errorf("gas limit = %d, want 200000", gasLimit)
+	}
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// Defaults of the wasm genesis
+const (
+	defaultChainID       = "landslide-test"
+	defaultVotingPeriod  = 48 * time.Hour
+	defaultUnbondingTime = 21 * 24 * time.Hour
+)
+
+// GenesisParams are the values rendered into the genesis template,
+// the e2e tests shorten the periods so the proposals and the unbondings end while the tests run.
+// The IBC tests run two chains which differ by the chain ID, GenTxs replace the gentx of the template
+// signed for the default chain ID.
+type GenesisParams struct {
+	ChainID       string
+	VotingPeriod  time.Duration
+	UnbondingTime time.Duration
+	GenTxs        []json.RawMessage
+}
+
+// DefaultGenesisParams returns the chain ID of the test chain and the periods of a regular chain
+func DefaultGenesisParams() GenesisParams {
+	return GenesisParams{
+		ChainID:       defaultChainID,
+		VotingPeriod:  defaultVotingPeriod,
+		UnbondingTime: defaultUnbondingTime,
+	}
+}
+
+// genesisValues are the template values, durations formatted like the proto JSON "172800s"
+type genesisValues struct {
+	ChainID               string
+	VotingPeriod          string
+	ExpeditedVotingPeriod string
+	UnbondingTime         string
+}
+
+// RenderGenesis renders the genesis template and checks the result is valid JSON.
+// The expedited voting period is half of the voting period, the gov module requires it to be shorter.
+func RenderGenesis(tmpl []byte, params GenesisParams) ([]byte, error) {
+	if params.ChainID == "" {
+		return nil, fmt.Errorf("chain ID is not set")
+	}
+	if params.VotingPeriod < 2*time.Second {
+		return nil, fmt.Errorf("voting period %s is too short", params.VotingPeriod)
+	}
+	if params.UnbondingTime < time.Second {
+		return nil, fmt.Errorf("unbonding time %s is too short", params.UnbondingTime)
+	}
+
+	t, err := template.New("genesis").Option("missingkey=error").Parse(string(tmpl))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing genesis template: %w", err)
+	}
+
+	values := genesisValues{
+		ChainID:               params.ChainID,
+		VotingPeriod:          formatDuration(params.VotingPeriod),
+		ExpeditedVotingPeriod: formatDuration(params.VotingPeriod / 2),
+		UnbondingTime:         formatDuration(params.UnbondingTime),
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, values); err != nil {
+		return nil, fmt.Errorf("error rendering genesis template: %w", err)
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("rendered genesis is not valid JSON")
+	}
+	if params.GenTxs != nil {
+		return replaceGenTxs(buf.Bytes(), params.GenTxs)
+	}
+	return buf.Bytes(), nil
+}
+
+// replaceGenTxs replaces app_state.genutil.gen_txs of the genesis, the rest of the document is kept as is
+func replaceGenTxs(genesis []byte, genTxs []json.RawMessage) ([]byte, error) {
+	var doc, appState, genutil map[string]json.RawMessage
+	if err := json.Unmarshal(genesis, &doc); err != nil {
+		return nil, fmt.Errorf("error decoding genesis: %w", err)
+	}
+	if err := json.Unmarshal(doc["app_state"], &appState); err != nil {
+		return nil, fmt.Errorf("error decoding genesis app_state: %w", err)
+	}
+	if err := json.Unmarshal(appState["genutil"], &genutil); err != nil {
+		return nil, fmt.Errorf("error decoding genesis genutil: %w", err)
+	}
+
+	var err error
+	if genutil["gen_txs"], err = json.Marshal(genTxs); err != nil {
+		return nil, fmt.Errorf("error encoding gen_txs: %w", err)
+	}
+	if appState["genutil"], err = json.Marshal(genutil); err != nil {
+		return nil, err
+	}
+	if doc["app_state"], err = json.Marshal(appState); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// formatDuration formats the duration in whole seconds like the proto JSON of google.protobuf.Duration
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64(d/time.Second))
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRenderGenesis(t *testing.T) {
+	tmpl, err := os.ReadFile("../cmd/data/wasm.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesis, err := RenderGenesis(tmpl, GenesisParams{ChainID: "landslide-ibc", VotingPeriod: 20 * time.Second, UnbondingTime: 30 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		ChainID  string `json:"chain_id"`
+		AppState struct {
+			Gov struct {
+				Params struct {
+					VotingPeriod          string `json:"voting_period"`
+					ExpeditedVotingPeriod string `json:"expedited_voting_period"`
+				} `json:"params"`
+			} `json:"gov"`
+			Staking struct {
+				Params struct {
+					UnbondingTime string `json:"unbonding_time"`
+				} `json:"params"`
+			} `json:"staking"`
+		} `json:"app_state"`
+	}
+	if err := json.Unmarshal(genesis, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.ChainID != "landslide-ibc" {
+		t.Errorf("chain id = %s, want landslide-ibc", doc.ChainID)
+	}
+	if got := doc.AppState.Gov.Params.VotingPeriod; got != "20s" {
+		t.Errorf("voting period = %s, want 20s", got)
+	}
+	if got := doc.AppState.Gov.Params.ExpeditedVotingPeriod; got != "10s" {
+		t.Errorf("expedited voting period = %s, want 10s", got)
+	}
+
+	if got := doc.AppState.Staking.Params.UnbondingTime; got != "30s" {
+		t.Errorf("unbonding time = %s, want 30s", got)
+	}
+
+	if _, err := RenderGenesis(tmpl, GenesisParams{ChainID: "landslide-ibc", UnbondingTime: time.Second}); err == nil {
+		t.Error("expected error for an empty voting period")
+	}
+}
+
+func TestRenderIBCGenesis(t *testing.T) {
+	tmpl, err := os.ReadFile("../cmd/data/wasm.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("../cmd/data/testdata/ibc_fixtures.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixtures, err := LoadFixtures(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paramsA, paramsB, err := IBCGenesisParams(fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type genTx struct {
+		Body struct {
+			Messages []struct {
+				ValidatorAddress string `json:"validator_address"`
+			} `json:"messages"`
+		} `json:"body"`
+	}
+	validator := func(params GenesisParams) (string, string) {
+		genesis, err := RenderGenesis(tmpl, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc struct {
+			ChainID  string `json:"chain_id"`
+			AppState struct {
+				Genutil struct {
+					GenTxs []genTx `json:"gen_txs"`
+				} `json:"genutil"`
+			} `json:"app_state"`
+		}
+		if err := json.Unmarshal(genesis, &doc); err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.AppState.Genutil.GenTxs) != 1 || len(doc.AppState.Genutil.GenTxs[0].Body.Messages) != 1 {
+			t.Fatalf("%s: expected one gentx with one message", doc.ChainID)
+		}
+		return doc.ChainID, doc.AppState.Genutil.GenTxs[0].Body.Messages[0].ValidatorAddress
+	}
+
+	chainA, validatorA := validator(paramsA)
+	if chainA != "landslide-test" || validatorA != "wasmvaloper1vcw0he5l9mu54zawg3h440p83ex70ccmw9pdnz" {
+		t.Errorf("chain A: %s with validator %s, want the template gentx", chainA, validatorA)
+	}
+	chainB, validatorB := validator(paramsB)
+	if chainB != IBCChainIDB || validatorB != "wasmvaloper1kng6sqkm0mjuh09cwz6u86f75lmeflj9znuuee" {
+		t.Errorf("chain B: %s with validator %s, want the gentx of user1", chainB, validatorB)
+	}
+
+	fixtures.ChainID = "landslide-test"
+	if _, _, err := IBCGenesisParams(fixtures); err == nil {
+		t.Error("expected error for the fixtures of another chain")
+	}
+}
@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ProposalStatus values of cosmos.gov.v1.ProposalStatus
+const (
+	ProposalStatusDepositPeriod uint64 = 1
+	ProposalStatusVotingPeriod  uint64 = 2
+	ProposalStatusPassed        uint64 = 3
+)
+
+// proposalMaxMemoCharacters is the memo limit set by the proposal of the wasm scenario
+const proposalMaxMemoCharacters = 512
+
+// RunGovTests runs a proposal through its lifecycle: user1 submits it, user2 completes the deposit,
+// both vote and the proposal is tallied in the EndBlock of the first block after the voting period.
+// The passed proposal must have executed its message and refunded the deposits.
+func RunGovTests(c *rpchttp.HTTP, log logging.Logger, fixtures *Fixtures, votingPeriod time.Duration, gasReport *GasReport) {
+	err := fixtures.Require(
+		[]string{"user1", "user2"},
+		[]string{"gov_delegate", "gov_submit_proposal", "gov_deposit", "gov_vote_user1", "gov_vote_user2", "gov_tick"},
+		[]string{"balances_user1", "balances_user2", "gov_proposal", "auth_params"},
+	)
+	if err != nil {
+		log.Fatal("invalid gov fixtures", zap.Error(err))
+		return
+	}
+
+	addressU1 := fixtures.Address("user1")
+	queryU1 := fixtures.Query("balances_user1")
+	addressU2 := fixtures.Address("user2")
+	queryU2 := fixtures.Query("balances_user2")
+
+	balanceU1 := GetBalance(c, log, queryU1, wasmDenom)
+	balanceU2 := GetBalance(c, log, queryU2, wasmDenom)
+
+	log.Info("Delegating 300000000 tokens of user1 to the genesis validator")
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("gov_delegate")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("gov_delegate"), balanceU1, 300000000)
+
+	log.Info("Submitting the proposal")
+	res, err := broadcastAndWait(c, log, fixtures.Tx("gov_submit_proposal"))
+	if err != nil {
+		return
+	}
+	gasReport.Record(c, log, "/cosmos.gov.v1.MsgSubmitProposal", res.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("gov_submit_proposal"), balanceU1, 5000000)
+	CheckProposalStatus(c, log, fixtures.Query("gov_proposal"), ProposalStatusDepositPeriod)
+
+	log.Info("Depositing the rest of the min deposit")
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("gov_deposit")); err != nil {
+		return
+	}
+	votingStart := time.Now()
+	balanceU2 = CheckFeeDeduction(c, log, addressU2, queryU2, fixtures.Tx("gov_deposit"), balanceU2, 5000000)
+	CheckProposalStatus(c, log, fixtures.Query("gov_proposal"), ProposalStatusVotingPeriod)
+
+	log.Info("Voting yes")
+	res, err = broadcastAndWait(c, log, fixtures.Tx("gov_vote_user1"))
+	if err != nil {
+		return
+	}
+	gasReport.Record(c, log, "/cosmos.gov.v1.MsgVote", res.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("gov_vote_user1"), balanceU1, 0)
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("gov_vote_user2")); err != nil {
+		return
+	}
+	balanceU2 = CheckFeeDeduction(c, log, addressU2, queryU2, fixtures.Tx("gov_vote_user2"), balanceU2, 0)
+
+	// the voting period ends at the block time of the deposit plus the voting period,
+	// blocks are only built for transactions, so one more is sent after it
+	if wait := time.Until(votingStart.Add(votingPeriod + 5*time.Second)); wait > 0 {
+		log.Info("waiting for the end of the voting period", zap.Duration("wait", wait))
+		<-time.After(wait)
+	}
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("gov_tick")); err != nil {
+		return
+	}
+
+	CheckProposalStatus(c, log, fixtures.Query("gov_proposal"), ProposalStatusPassed)
+	CheckMaxMemoCharacters(c, log, fixtures.Query("auth_params"), proposalMaxMemoCharacters)
+
+	// the deposits of the passed proposal are refunded
+	CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("gov_tick"), balanceU1, -5000000)
+	CheckBalance(c, log, addressU2, queryU2, balanceU2+5000000)
+
+	log.Info("Success! proposal passed and executed")
+}
+
+// CheckProposalStatus checks the status of the proposal
+func CheckProposalStatus(c *rpchttp.HTTP, log logging.Logger, querystring string, expected uint64) {
+	value, ok := abciQuery(c, log, "/cosmos.gov.v1.Query/Proposal", querystring)
+	if !ok {
+		return
+	}
+
+	// QueryProposalResponse: 1 - proposal, Proposal: 3 - status
+	var status uint64
+	err := walkMessage(value, func(num protowire.Number, proposal []byte) error {
+		if num != 1 {
+			return nil
+		}
+		var err error
+		status, err = varintField(proposal, 3)
+		return err
+	})
+	if err != nil {
+		log.Fatal("error decoding proposal", zap.Error(err))
+		return
+	}
+	if status != expected {
+		log.Fatal("unexpected proposal status", zap.Uint64("expected", expected), zap.Uint64("status", status))
+		return
+	}
+
+	log.Info("proposal status check success", zap.Uint64("status", status))
+}
+
+// CheckMaxMemoCharacters checks the memo limit of the auth params
+func CheckMaxMemoCharacters(c *rpchttp.HTTP, log logging.Logger, querystring string, expected uint64) {
+	value, ok := abciQuery(c, log, "/cosmos.auth.v1beta1.Query/Params", querystring)
+	if !ok {
+		return
+	}
+
+	// QueryParamsResponse: 1 - params, Params: 1 - max_memo_characters
+	var maxMemo uint64
+	err := walkMessage(value, func(num protowire.Number, params []byte) error {
+		if num != 1 {
+			return nil
+		}
+		var err error
+		maxMemo, err = varintField(params, 1)
+		return err
+	})
+	if err != nil {
+		log.Fatal("error decoding auth params", zap.Error(err))
+		return
+	}
+	if maxMemo != expected {
+		log.Fatal("proposal not executed", zap.Uint64("expected", expected), zap.Uint64("maxMemoCharacters", maxMemo))
+		return
+	}
+
+	log.Info("auth params check success", zap.Uint64("maxMemoCharacters", maxMemo))
+}
+
+// varintField returns the value of the varint field of the protobuf message, zero if it is not set
+func varintField(bz []byte, field protowire.Number) (uint64, error) {
+	var value uint64
+	for len(bz) > 0 {
+		num, typ, l := protowire.ConsumeTag(bz)
+		if l < 0 {
+			return 0, protowire.ParseError(l)
+		}
+		bz = bz[l:]
+
+		if num == field && typ == protowire.VarintType {
+			v, l := protowire.ConsumeVarint(bz)
+			if l < 0 {
+				return 0, protowire.ParseError(l)
+			}
+			value = v
+			bz = bz[l:]
+			continue
+		}
+
+		l = protowire.ConsumeFieldValue(num, typ, bz)
+		if l < 0 {
+			return 0, protowire.ParseError(l)
+		}
+		bz = bz[l:]
+	}
+	return value, nil
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+)
+
+// IBCChainIDB is the chain ID of the second chain of the IBC tests, the first one has the default chain ID
+const IBCChainIDB = "landslide-ibc"
+
+// IBCGenesisParams returns the genesis params of the two chains of the IBC tests, the first one has the gentx
+// of the template, the second one takes the gentx of the fixtures signed for its chain ID by tools/payload_gen
+func IBCGenesisParams(fixtures *Fixtures) (GenesisParams, GenesisParams, error) {
+	paramsA := DefaultGenesisParams()
+	paramsB := DefaultGenesisParams()
+	paramsB.ChainID = IBCChainIDB
+
+	if fixtures.ChainID != paramsB.ChainID {
+		return paramsA, paramsB, fmt.Errorf("fixtures %s are signed for %s, expected %s", fixtures.Scenario, fixtures.ChainID, paramsB.ChainID)
+	}
+	if err := fixtures.Require(nil, []string{"gentx"}, nil); err != nil {
+		return paramsA, paramsB, err
+	}
+	gentx := fixtures.Txs["gentx"].JSON
+	if len(gentx) == 0 {
+		return paramsA, paramsB, fmt.Errorf("fixtures %s: gentx has no signed JSON", fixtures.Scenario)
+	}
+	paramsB.GenTxs = []json.RawMessage{gentx}
+	return paramsA, paramsB, nil
+}
+
+// RunIBCTests runs the IBC tests of tools/ibc against the two chains: the in-process relayer of the tool
+// creates the clients, the connection and the channels, then the tool checks an ICS-20 transfer round trip
+// and an interchain account.
+func RunIBCTests(toolDir, rpcA, chainIDA, rpcB, chainIDB string, log logging.Logger) error {
+	log.Info("running IBC tests",
+		zap.String("dir", toolDir),
+		zap.String("chainA", chainIDA),
+		zap.String("chainB", chainIDB),
+	)
+	err := runGoTool(toolDir,
+		"-rpc-a", rpcA,
+		"-chain-id-a", chainIDA,
+		"-rpc-b", rpcB,
+		"-chain-id-b", chainIDB,
+	)
+	if err != nil {
+		return fmt.Errorf("IBC tests failed: %w", err)
+	}
+
+	log.Info("Success! IBC tests passed")
+	return nil
+}
@@ -0,0 +1,272 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cometbft/cometbft/rpc/core/types"
+	"go.uber.org/zap"
+)
+
+const searchPerPage = 1
+
+// maxBlockEventQueries - number of finalize_block event types the blocks are searched by
+const maxBlockEventQueries = 4
+
+// CheckTxSearch searches transactions by the query and checks that all expected
+// transactions are returned, paginated without duplicates and ordered by height
+func CheckTxSearch(c *rpchttp.HTTP, log logging.Logger, query string, expected [][]byte) {
+	for _, orderBy := range []string{"asc", "desc"} {
+		txs, err := txSearchAll(c, query, orderBy)
+		if err != nil {
+			log.Fatal("TxSearch failed", zap.String("query", query), zap.Error(err))
+			return
+		}
+
+		seen := make(map[string]struct{}, len(txs))
+		for i, tx := range txs {
+			if _, ok := seen[string(tx.Hash)]; ok {
+				log.Fatal("TxSearch returned duplicated tx", zap.String("query", query), zap.Stringer("hash", tx.Hash))
+				return
+			}
+			seen[string(tx.Hash)] = struct{}{}
+
+			if i == 0 {
+				continue
+			}
+			prev := txs[i-1]
+			if orderBy == "asc" && (prev.Height > tx.Height || prev.Height == tx.Height && prev.Index > tx.Index) ||
+				orderBy == "desc" && (prev.Height < tx.Height || prev.Height == tx.Height && prev.Index < tx.Index) {
+				log.Fatal("TxSearch returned txs in wrong order",
+					zap.String("query", query),
+					zap.String("orderBy", orderBy),
+					zap.Int64("prevHeight", prev.Height),
+					zap.Int64("height", tx.Height),
+				)
+				return
+			}
+		}
+
+		for _, hash := range expected {
+			if _, ok := seen[string(hash)]; !ok {
+				log.Fatal("TxSearch did not return expected tx", zap.String("query", query), zap.String("hash", fmt.Sprintf("%X", hash)))
+				return
+			}
+		}
+	}
+
+	log.Info("TxSearch success", zap.String("query", query), zap.Int("expected", len(expected)))
+}
+
+// txSearchAll walks through all the pages of the tx search result
+func txSearchAll(c *rpchttp.HTTP, query, orderBy string) ([]*coretypes.ResultTx, error) {
+	var (
+		txs     []*coretypes.ResultTx
+		perPage = searchPerPage
+	)
+	for page := 1; ; page++ {
+		res, err := c.TxSearch(context.Background(), query, false, &page, &perPage, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Txs) > perPage {
+			return nil, fmt.Errorf("page %d has %d txs, expected at most %d", page, len(res.Txs), perPage)
+		}
+		txs = append(txs, res.Txs...)
+		if len(txs) >= res.TotalCount || len(res.Txs) == 0 {
+			if len(txs) != res.TotalCount {
+				return nil, fmt.Errorf("got %d txs, total count is %d", len(txs), res.TotalCount)
+			}
+			return txs, nil
+		}
+	}
+}
+
+// CheckBlockSearch searches blocks by the query and checks that all expected
+// heights are returned, paginated without duplicates and ordered by height
+func CheckBlockSearch(c *rpchttp.HTTP, log logging.Logger, query string, expected []int64) {
+	for _, orderBy := range []string{"asc", "desc"} {
+		heights, err := blockSearchAll(c, query, orderBy)
+		if err != nil {
+			log.Fatal("BlockSearch failed", zap.String("query", query), zap.Error(err))
+			return
+		}
+
+		seen := make(map[int64]struct{}, len(heights))
+		for i, h := range heights {
+			if _, ok := seen[h]; ok {
+				log.Fatal("BlockSearch returned duplicated block", zap.String("query", query), zap.Int64("height", h))
+				return
+			}
+			seen[h] = struct{}{}
+
+			if i > 0 && (orderBy == "asc" && heights[i-1] > h || orderBy == "desc" && heights[i-1] < h) {
+				log.Fatal("BlockSearch returned blocks in wrong order", zap.String("query", query), zap.String("orderBy", orderBy))
+				return
+			}
+		}
+
+		for _, h := range expected {
+			if _, ok := seen[h]; !ok {
+				log.Fatal("BlockSearch did not return expected block", zap.String("query", query), zap.Int64("height", h))
+				return
+			}
+		}
+	}
+
+	log.Info("BlockSearch success", zap.String("query", query), zap.Int("expected", len(expected)))
+}
+
+// blockSearchAll walks through all the pages of the block search result, returns the heights
+func blockSearchAll(c *rpchttp.HTTP, query, orderBy string) ([]int64, error) {
+	var (
+		heights []int64
+		perPage = searchPerPage
+	)
+	for page := 1; ; page++ {
+		res, err := c.BlockSearch(context.Background(), query, &page, &perPage, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range res.Blocks {
+			heights = append(heights, b.Block.Height)
+		}
+		if len(heights) >= res.TotalCount || len(res.Blocks) == 0 {
+			return heights, nil
+		}
+	}
+}
+
+// CheckBlockEventSearch searches blocks by the attributes of the finalize_block events emitted
+// in the height range, one query per event type, and checks the search returns
+// exactly the heights of the blocks which emitted the attribute
+func CheckBlockEventSearch(c *rpchttp.HTTP, log logging.Logger, from, to int64) {
+	var (
+		queries []string
+		emitted = make(map[string]map[int64]struct{})
+	)
+	for h := from; h <= to; h++ {
+		height := h
+		res, err := c.BlockResults(context.Background(), &height)
+		if err != nil {
+			log.Fatal("error getting block results", zap.Int64("height", h), zap.Error(err))
+			return
+		}
+
+		for _, event := range res.FinalizeBlockEvents {
+			for _, attr := range event.Attributes {
+				if !attr.Index || attr.Key == "mode" || attr.Value == "" || strings.Contains(attr.Value, "'") {
+					continue
+				}
+				query := fmt.Sprintf("%s.%s='%s'", event.Type, attr.Key, attr.Value)
+				if _, ok := emitted[query]; !ok {
+					emitted[query] = make(map[int64]struct{})
+					queries = append(queries, query)
+				}
+				emitted[query][h] = struct{}{}
+			}
+		}
+	}
+	if len(queries) == 0 {
+		log.Fatal("no finalize_block events to search blocks by", zap.Int64("from", from), zap.Int64("to", to))
+		return
+	}
+
+	searched := make(map[string]struct{})
+	for _, query := range queries {
+		eventType := query[:strings.Index(query, ".")]
+		if _, ok := searched[eventType]; ok || len(searched) == maxBlockEventQueries {
+			continue
+		}
+		searched[eventType] = struct{}{}
+
+		expected := make([]int64, 0, len(emitted[query]))
+		for h := range emitted[query] {
+			expected = append(expected, h)
+		}
+		sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+
+		rangeQuery := fmt.Sprintf("%s AND block.height >= %d AND block.height <= %d", query, from, to)
+		heights, err := blockSearchAll(c, rangeQuery, "asc")
+		if err != nil {
+			log.Fatal("BlockSearch failed", zap.String("query", rangeQuery), zap.Error(err))
+			return
+		}
+		if !slices.Equal(heights, expected) {
+			log.Fatal("BlockSearch returned unexpected blocks",
+				zap.String("query", rangeQuery),
+				zap.Int64s("expected", expected),
+				zap.Int64s("got", heights),
+			)
+			return
+		}
+		log.Info("BlockSearch by finalize_block event success", zap.String("query", rangeQuery), zap.Int64s("heights", heights))
+	}
+}
+
+// CheckTxProof fetches the transaction with proof and verifies it against the data hash of the block
+func CheckTxProof(c *rpchttp.HTTP, log logging.Logger, hash []byte) {
+	res, err := c.Tx(context.Background(), hash, true)
+	if err != nil {
+		log.Fatal("error getting transaction with proof", zap.Error(err))
+		return
+	}
+
+	block, err := c.Block(context.Background(), &res.Height)
+	if err != nil {
+		log.Fatal("error Block", zap.Error(err))
+		return
+	}
+
+	if !bytes.Equal(res.Proof.Data, res.Tx) {
+		log.Fatal("tx proof data does not match the transaction", zap.Stringer("hash", res.Hash))
+		return
+	}
+
+	if err := res.Proof.Validate(block.Block.DataHash); err != nil {
+		log.Fatal("tx proof validation failed",
+			zap.Stringer("hash", res.Hash),
+			zap.Stringer("dataHash", block.Block.DataHash),
+			zap.Error(err),
+		)
+		return
+	}
+
+	log.Info("Tx proof success", zap.Stringer("hash", res.Hash), zap.Int64("height", res.Height))
+}
+
+// RunIndexerTests checks tx search, block search and tx proofs
+// for the transactions committed by the wasm end-to-end tests
+func RunIndexerTests(
+	c *rpchttp.HTTP,
+	log logging.Logger,
+	sender, recipient, contractAddress string,
+	sendHash, storeHash, instantiateHash, executeHash []byte,
+) {
+	log.Info("checking tx and block indexers")
+
+	CheckTxSearch(c, log, fmt.Sprintf("message.sender='%s'", sender), [][]byte{sendHash, storeHash, instantiateHash, executeHash})
+	CheckTxSearch(c, log, fmt.Sprintf("transfer.recipient='%s'", recipient), [][]byte{sendHash})
+	CheckTxSearch(c, log, fmt.Sprintf("instantiate._contract_address='%s'", contractAddress), [][]byte{instantiateHash})
+
+	var heights []int64
+	for _, hash := range [][]byte{sendHash, storeHash, instantiateHash, executeHash} {
+		res, err := c.Tx(context.Background(), hash, false)
+		if err != nil {
+			log.Fatal("error getting transaction", zap.Error(err))
+			return
+		}
+		heights = append(heights, res.Height)
+
+		CheckTxProof(c, log, hash)
+	}
+
+	CheckBlockSearch(c, log, fmt.Sprintf("block.height >= %d AND block.height <= %d", heights[0], heights[len(heights)-1]), heights)
+	CheckBlockEventSearch(c, log, heights[0], heights[len(heights)-1])
+}
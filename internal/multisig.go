@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"github.com/ava-labs/avalanchego/utils/logging"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"go.uber.org/zap"
+)
+
+// RunMultisigTests sends tokens and instantiates a contract from the 2-of-3 multisig account
+// funded in the genesis, the transactions carry the combined signatures of two of its keys
+func RunMultisigTests(c *rpchttp.HTTP, log logging.Logger, fixtures *Fixtures, gasReport *GasReport) {
+	err := fixtures.Require(
+		[]string{"multisig", "user2"},
+		[]string{"multisig_send", "multisig_instantiate"},
+		[]string{"balances_multisig", "balances_user2"},
+	)
+	if err != nil {
+		log.Fatal("invalid multisig fixtures", zap.Error(err))
+		return
+	}
+
+	addressMultisig := fixtures.Address("multisig")
+	queryMultisig := fixtures.Query("balances_multisig")
+	queryU2 := fixtures.Query("balances_user2")
+
+	balanceMultisig := GetBalance(c, log, queryMultisig, wasmDenom)
+	balanceU2 := GetBalance(c, log, queryU2, wasmDenom)
+
+	log.Info("Sending 7000000 tokens from the multisig account to user2")
+	txSend := fixtures.Tx("multisig_send")
+	resSend, err := BroadCastTxAsync(c, log, txSend)
+	if err != nil {
+		return
+	}
+	if _, err := WaitTxCommitted(c, log, resSend.Hash); err != nil {
+		return
+	}
+
+	gasReport.Record(c, log, "/cosmos.bank.v1beta1.MsgSend (multisig)", resSend.Hash)
+	balanceMultisig = CheckFeeDeduction(c, log, addressMultisig, queryMultisig, txSend, balanceMultisig, 7000000)
+	if got := GetBalance(c, log, queryU2, wasmDenom); got != balanceU2+7000000 {
+		log.Fatal("unexpected balance of the multisig recipient",
+			zap.Int64("before", balanceU2),
+			zap.Int64("after", got),
+		)
+		return
+	}
+
+	log.Info("Instantiating wasm contract from the multisig account")
+	txInstantiate := fixtures.Tx("multisig_instantiate")
+	resInstantiate, err := BroadCastTxAsync(c, log, txInstantiate)
+	if err != nil {
+		return
+	}
+	resTx, err := WaitTxCommitted(c, log, resInstantiate.Hash)
+	if err != nil {
+		return
+	}
+
+	expected := fixtures.Contract("nameservice_multisig")
+	var contractAddress string
+	for _, event := range resTx.TxResult.GetEvents() {
+		if event.Type != "instantiate" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "_contract_address" {
+				contractAddress = attr.Value
+			}
+		}
+	}
+	if contractAddress != expected {
+		log.Fatal("unexpected address of the multisig contract",
+			zap.String("expected", expected),
+			zap.String("got", contractAddress),
+		)
+		return
+	}
+
+	gasReport.Record(c, log, "/cosmwasm.wasm.v1.MsgInstantiateContract (multisig)", resInstantiate.Hash)
+	CheckFeeDeduction(c, log, addressMultisig, queryMultisig, txInstantiate, balanceMultisig, 20000)
+
+	log.Info("Success! multisig transactions committed", zap.String("contract_address", expected))
+}
@@ -0,0 +1,299 @@
+package internal
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cometbft/cometbft/rpc/core/types"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// BondStatus values of cosmos.staking.v1beta1.BondStatus
+var bondStatusNames = map[uint64]string{
+	1: "unbonded",
+	2: "unbonding",
+	3: "bonded",
+}
+
+// RunStakingTests delegates, redelegates to a validator created by user2, undelegates and withdraws
+// the rewards of user1. The delegations are checked by the staking queries, the rewards must accrue
+// over the blocks and the unbonding must complete in the EndBlock after the unbonding time.
+// The transactions of user1 withdraw the pending rewards of the changed delegations,
+// so the balance checks add the withdrawn rewards reported by the events.
+func RunStakingTests(c *rpchttp.HTTP, log logging.Logger, fixtures *Fixtures, unbondingTime time.Duration, gasReport *GasReport) {
+	err := fixtures.Require(
+		[]string{"user1", "user2"},
+		[]string{
+			"staking_create_validator", "staking_delegate", "staking_tick_1", "staking_tick_2",
+			"staking_redelegate", "staking_undelegate", "staking_tick_3", "staking_withdraw_rewards",
+		},
+		[]string{
+			"balances_user1", "balances_user2", "staking_rewards_user1", "staking_delegation_user1",
+			"staking_redelegation_user1", "staking_redelegations_user1", "staking_unbondings_user1", "staking_validator_node2",
+		},
+	)
+	if err != nil {
+		log.Fatal("invalid staking fixtures", zap.Error(err))
+		return
+	}
+
+	addressU1 := fixtures.Address("user1")
+	queryU1 := fixtures.Query("balances_user1")
+	addressU2 := fixtures.Address("user2")
+	queryU2 := fixtures.Query("balances_user2")
+
+	balanceU1 := GetBalance(c, log, queryU1, wasmDenom)
+	balanceU2 := GetBalance(c, log, queryU2, wasmDenom)
+	// user1 delegated 300000000 to vote in the gov tests
+	delegated := DelegationBalance(c, log, fixtures.Query("staking_delegation_user1"))
+
+	log.Info("Creating the validator of user2")
+	res, err := broadcastAndWait(c, log, fixtures.Tx("staking_create_validator"))
+	if err != nil {
+		return
+	}
+	gasReport.Record(c, log, "/cosmos.staking.v1beta1.MsgCreateValidator", res.Hash)
+	CheckFeeDeduction(c, log, addressU2, queryU2, fixtures.Tx("staking_create_validator"), balanceU2, 2000000)
+	LogValidatorStatus(c, log, fixtures.Query("staking_validator_node2"))
+
+	log.Info("Delegating 10000000 tokens of user1")
+	res, err = broadcastAndWait(c, log, fixtures.Tx("staking_delegate"))
+	if err != nil {
+		return
+	}
+	gasReport.Record(c, log, "/cosmos.staking.v1beta1.MsgDelegate", res.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("staking_delegate"), balanceU1, 10000000-WithdrawnRewards(res, addressU1))
+	delegated = CheckDelegation(c, log, fixtures.Query("staking_delegation_user1"), delegated+10000000)
+
+	// the rewards of the block are allocated in its BeginBlock to the validators which signed the previous one
+	rewards := DelegationRewards(c, log, fixtures.Query("staking_rewards_user1"))
+	for _, tick := range []string{"staking_tick_1", "staking_tick_2"} {
+		if _, err := broadcastAndWait(c, log, fixtures.Tx(tick)); err != nil {
+			return
+		}
+		balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx(tick), balanceU1, 0)
+
+		accrued := DelegationRewards(c, log, fixtures.Query("staking_rewards_user1"))
+		if accrued.Cmp(rewards) <= 0 {
+			log.Fatal("delegation rewards did not accrue",
+				zap.Stringer("before", rewards),
+				zap.Stringer("after", accrued),
+			)
+			return
+		}
+		log.Info("delegation rewards accrued", zap.Stringer("before", rewards), zap.Stringer("after", accrued))
+		rewards = accrued
+	}
+
+	log.Info("Redelegating 4000000 tokens of user1 to the validator of user2")
+	res, err = broadcastAndWait(c, log, fixtures.Tx("staking_redelegate"))
+	if err != nil {
+		return
+	}
+	gasReport.Record(c, log, "/cosmos.staking.v1beta1.MsgBeginRedelegate", res.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("staking_redelegate"), balanceU1, -WithdrawnRewards(res, addressU1))
+	delegated = CheckDelegation(c, log, fixtures.Query("staking_delegation_user1"), delegated-4000000)
+	CheckDelegation(c, log, fixtures.Query("staking_redelegation_user1"), 4000000)
+	CheckResponseCount(c, log, "/cosmos.staking.v1beta1.Query/Redelegations", fixtures.Query("staking_redelegations_user1"), 1)
+
+	log.Info("Undelegating 3000000 tokens of user1")
+	res, err = broadcastAndWait(c, log, fixtures.Tx("staking_undelegate"))
+	if err != nil {
+		return
+	}
+	unbondingStart := time.Now()
+	gasReport.Record(c, log, "/cosmos.staking.v1beta1.MsgUndelegate", res.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("staking_undelegate"), balanceU1, -WithdrawnRewards(res, addressU1))
+	CheckDelegation(c, log, fixtures.Query("staking_delegation_user1"), delegated-3000000)
+	CheckResponseCount(c, log, "/cosmos.staking.v1beta1.Query/DelegatorUnbondingDelegations", fixtures.Query("staking_unbondings_user1"), 1)
+
+	// the unbonding completes at the block time of the undelegation plus the unbonding time,
+	// blocks are only built for transactions, so one more is sent after it
+	if wait := time.Until(unbondingStart.Add(unbondingTime + 5*time.Second)); wait > 0 {
+		log.Info("waiting for the end of the unbonding time", zap.Duration("wait", wait))
+		<-time.After(wait)
+	}
+	if _, err := broadcastAndWait(c, log, fixtures.Tx("staking_tick_3")); err != nil {
+		return
+	}
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("staking_tick_3"), balanceU1, -3000000)
+	CheckResponseCount(c, log, "/cosmos.staking.v1beta1.Query/DelegatorUnbondingDelegations", fixtures.Query("staking_unbondings_user1"), 0)
+
+	log.Info("Withdrawing the rewards of user1")
+	res, err = broadcastAndWait(c, log, fixtures.Tx("staking_withdraw_rewards"))
+	if err != nil {
+		return
+	}
+	gasReport.Record(c, log, "/cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward", res.Hash)
+	withdrawn := WithdrawnRewards(res, addressU1)
+	if withdrawn <= 0 {
+		log.Fatal("no rewards withdrawn", zap.Int64("withdrawn", withdrawn))
+		return
+	}
+	CheckFeeDeduction(c, log, addressU1, queryU1, fixtures.Tx("staking_withdraw_rewards"), balanceU1, -withdrawn)
+	LogValidatorStatus(c, log, fixtures.Query("staking_validator_node2"))
+
+	log.Info("Success! staking checks passed", zap.Int64("withdrawn", withdrawn))
+}
+
+// DelegationBalance returns the balance of the delegation, zero if it does not exist
+func DelegationBalance(c *rpchttp.HTTP, log logging.Logger, querystring string) int64 {
+	value, ok := abciQuery(c, log, "/cosmos.staking.v1beta1.Query/Delegation", querystring)
+	if !ok {
+		return 0
+	}
+
+	// QueryDelegationResponse: 1 - delegation_response, DelegationResponse: 2 - balance
+	var amount int64
+	err := walkMessage(value, func(num protowire.Number, resp []byte) error {
+		if num != 1 {
+			return nil
+		}
+		return walkMessage(resp, func(num protowire.Number, balance []byte) error {
+			if num != 2 {
+				return nil
+			}
+			var err error
+			_, amount, err = decodeCoin(balance)
+			return err
+		})
+	})
+	if err != nil {
+		log.Fatal("error decoding delegation", zap.Error(err))
+		return 0
+	}
+	return amount
+}
+
+// CheckDelegation checks the balance of the delegation, returns it
+func CheckDelegation(c *rpchttp.HTTP, log logging.Logger, querystring string, expected int64) int64 {
+	amount := DelegationBalance(c, log, querystring)
+	if amount != expected {
+		log.Fatal("unexpected delegation", zap.Int64("expected", expected), zap.Int64("amount", amount))
+		return amount
+	}
+
+	log.Info("delegation check success", zap.Int64("amount", amount))
+	return amount
+}
+
+// DelegationRewards returns the pending rewards of the delegation in the wasm denom
+// with the 18 decimals of the decimal coins
+func DelegationRewards(c *rpchttp.HTTP, log logging.Logger, querystring string) *big.Int {
+	rewards := new(big.Int)
+	value, ok := abciQuery(c, log, "/cosmos.distribution.v1beta1.Query/DelegationRewards", querystring)
+	if !ok {
+		return rewards
+	}
+
+	// QueryDelegationRewardsResponse: 1 - repeated DecCoin rewards, DecCoin: 1 - denom, 2 - amount
+	err := walkMessage(value, func(num protowire.Number, coin []byte) error {
+		if num != 1 {
+			return nil
+		}
+		var denom, amount string
+		err := walkMessage(coin, func(num protowire.Number, v []byte) error {
+			switch num {
+			case 1:
+				denom = string(v)
+			case 2:
+				amount = string(v)
+			}
+			return nil
+		})
+		if err != nil || denom != wasmDenom {
+			return err
+		}
+		if _, ok := rewards.SetString(amount, 10); !ok {
+			return fmt.Errorf("invalid reward amount %q", amount)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal("error decoding delegation rewards", zap.Error(err))
+	}
+	return rewards
+}
+
+// WithdrawnRewards sums the rewards of the delegator withdrawn by the transaction in the wasm denom
+func WithdrawnRewards(res *coretypes.ResultTx, delegator string) int64 {
+	var withdrawn int64
+	for _, event := range res.TxResult.GetEvents() {
+		if event.Type != "withdraw_rewards" {
+			continue
+		}
+		var amount string
+		eventDelegator := ""
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case "amount":
+				amount = attr.Value
+			case "delegator":
+				eventDelegator = attr.Value
+			}
+		}
+		if eventDelegator != delegator {
+			continue
+		}
+		// the coins are formatted like "123stake,4other"
+		for _, coin := range strings.Split(amount, ",") {
+			if !strings.HasSuffix(coin, wasmDenom) {
+				continue
+			}
+			v, err := strconv.ParseInt(strings.TrimSuffix(coin, wasmDenom), 10, 64)
+			if err == nil {
+				withdrawn += v
+			}
+		}
+	}
+	return withdrawn
+}
+
+// LogValidatorStatus logs the bond status and the jail flag of the validator,
+// LandslideVM does not apply the validator updates of the staking module to its consensus
+func LogValidatorStatus(c *rpchttp.HTTP, log logging.Logger, querystring string) {
+	value, ok := abciQuery(c, log, "/cosmos.staking.v1beta1.Query/Validator", querystring)
+	if !ok {
+		return
+	}
+
+	// QueryValidatorResponse: 1 - validator, Validator: 3 - jailed, 4 - status, 5 - tokens
+	var (
+		jailed, status uint64
+		tokens         string
+	)
+	err := walkMessage(value, func(num protowire.Number, validator []byte) error {
+		if num != 1 {
+			return nil
+		}
+		var err error
+		if jailed, err = varintField(validator, 3); err != nil {
+			return err
+		}
+		if status, err = varintField(validator, 4); err != nil {
+			return err
+		}
+		return walkMessage(validator, func(num protowire.Number, v []byte) error {
+			if num == 5 {
+				tokens = string(v)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		log.Fatal("error decoding validator", zap.Error(err))
+		return
+	}
+
+	log.Info("validator status",
+		zap.String("status", bondStatusNames[status]),
+		zap.Bool("jailed", jailed == 1),
+		zap.String("tokens", tokens),
+	)
+}
@@ -0,0 +1,17 @@
+package internal
+
+import (
+	"os"
+	"os/exec"
+)
+
+// runGoTool runs the tool with `go run` from its directory. The tools are built on chainclient and the SDK,
+// which require a version of pebble incompatible with avalanchego, so they have their own modules
+// and the runner uses them as separate processes.
+func runGoTool(toolDir string, args ...string) error {
+	cmd := exec.Command("go", append([]string{"run", "."}, args...)...)
+	cmd.Dir = toolDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -13,7 +15,17 @@ import (
 	// bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
-func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex string) {
+func RunWASMTests(rpcAddrs []string, log logging.Logger, fixtures *Fixtures, genesisParams GenesisParams, gasReportPath string) {
+	err := fixtures.Require(
+		[]string{"user1", "user2"},
+		[]string{"send", "batch_send", "store_nameservice", "instantiate_nameservice", "register_cidt", "register_out_of_gas"},
+		[]string{"balances_user1", "balances_user2", "resolve_cidt"},
+	)
+	if err != nil {
+		log.Fatal("invalid wasm fixtures", zap.Error(err))
+		return
+	}
+
 	<-time.After(2 * time.Second)
 
 	c, err := rpchttp.New(rpcAddrs[0], "/websocket")
@@ -24,20 +36,22 @@ func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex st
 	Info(c, log)
 
 	// Define the path for the balance query
-	addressU1 := "wasm1kng6sqkm0mjuh09cwz6u86f75lmeflj9h0fqhr"
-	encodedQueryAllBalancesRequestU1 := "0a2b7761736d316b6e673673716b6d306d6a7568303963777a367538366637356c6d65666c6a39683066716872"
-	addressU2 := "wasm1c4w4jxdkvj3ygdycdkjy98jve6w0d7257eqfx9"
-	encodedQueryAllBalancesRequestU2 := "0a2b7761736d31633477346a78646b766a337967647963646b6a7939386a766536773064373235376571667839"
+	addressU1 := fixtures.Address("user1")
+	encodedQueryAllBalancesRequestU1 := fixtures.Query("balances_user1")
+	addressU2 := fixtures.Address("user2")
+	encodedQueryAllBalancesRequestU2 := fixtures.Query("balances_user2")
 
 	GetBalances(c, log, addressU1, encodedQueryAllBalancesRequestU1)
 	GetBalances(c, log, addressU2, encodedQueryAllBalancesRequestU2)
 
+	gasReport := &GasReport{}
+	balanceU1 := GetBalance(c, log, encodedQueryAllBalancesRequestU1, wasmDenom)
+
 	// transfer tokens
 	// bank.MsgSend hex encoded: from user1 to user2, amount 5000000
 	log.Info("Sending 5000000 tokens from user1 to user2")
-	// seq 9
-	txSend := "0a8f010a8c010a1c2f636f736d6f732e62616e6b2e763162657461312e4d736753656e64126c0a2b7761736d316b6e673673716b6d306d6a7568303963777a367538366637356c6d65666c6a39683066716872122b7761736d31633477346a78646b766a337967647963646b6a7939386a7665367730643732353765716678391a100a057374616b6512073530303030303012670a4e0a460a1f2f636f736d6f732e63727970746f2e736563703235366b312e5075624b657912230a2103ca7cd136cf54b73631e5c40850fc78cca77fdcac94ea4abe2768797c8bc7b71312040a02080112150a0f0a057374616b65120631303030303010c09a0c1a40b1250c76eb38e062e141b5a5dc1badad0c21150850bebb5e3ad9e3ad109dbb5f654daa40f7c46018781f35d0a2ee302d691dc5ebf4c4ea7d0059488e537b3252"
-	_, err = BroadCastTxAsync(c, log, txSend)
+	txSend := fixtures.Tx("send")
+	resSend, err := BroadCastTxAsync(c, log, txSend)
 	if err != nil {
 		return
 	}
@@ -48,11 +62,33 @@ func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex st
 	GetBalances(c, log, addressU1, encodedQueryAllBalancesRequestU1)
 	GetBalances(c, log, addressU2, encodedQueryAllBalancesRequestU2)
 
+	gasReport.Record(c, log, "/cosmos.bank.v1beta1.MsgSend", resSend.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, encodedQueryAllBalancesRequestU1, txSend, balanceU1, 5000000)
+
+	// three transfers in one transaction, the events of each message carry its msg_index
+	log.Info("Sending 1000, 2000 and 3000 tokens from user1 to user2 in one transaction")
+	txBatchSend := fixtures.Tx("batch_send")
+	resBatchSend, err := BroadCastTxAsync(c, log, txBatchSend)
+	if err != nil {
+		return
+	}
+	batchSendResult, err := WaitTxCommitted(c, log, resBatchSend.Hash)
+	if err != nil {
+		return
+	}
+	if err := CheckMsgEvents(batchSendResult, "transfer", "amount", []string{"1000stake", "2000stake", "3000stake"}); err != nil {
+		log.Fatal("batched transaction events mismatch", zap.Error(err))
+		return
+	}
+	log.Info("Success! events of the batched messages are attributed to their msg_index")
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, encodedQueryAllBalancesRequestU1, txBatchSend, balanceU1, 6000)
+
 	<-time.After(1 * time.Second)
 
 	// deploy wasm contract
+	nameserviceDeployHex := fixtures.Tx("store_nameservice")
 	log.Info("Deploying wasm contract")
-	res, err := BroadCastTxAsync(c, log, nameserviceDeployHex)
+	resStore, err := BroadCastTxAsync(c, log, nameserviceDeployHex)
 	if err != nil {
 		log.Fatal("error deploying wasm contract", zap.Error(err))
 	}
@@ -62,7 +98,7 @@ func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex st
 		log.Info("waiting for transaction to be committed")
 
 		<-time.After(5 * time.Second)
-		deployResultTx, err := c.Tx(context.Background(), res.Hash, false)
+		deployResultTx, err := c.Tx(context.Background(), resStore.Hash, false)
 		if err != nil {
 			log.Fatal("error getting transaction", zap.Error(err))
 			continue
@@ -77,10 +113,13 @@ func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex st
 		break
 	}
 
+	gasReport.Record(c, log, "/cosmwasm.wasm.v1.MsgStoreCode", resStore.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, encodedQueryAllBalancesRequestU1, nameserviceDeployHex, balanceU1, 0)
+
 	// instantiate wasm contract
 	log.Info("Instantiating wasm contract")
-	txInstantiate := "0ae3010ae0010a282f636f736d7761736d2e7761736d2e76312e4d7367496e7374616e7469617465436f6e747261637412b3010a2b7761736d316b6e673673716b6d306d6a7568303963777a367538366637356c6d65666c6a396830667168721801220774657374696e672a697b2270757263686173655f7072696365223a7b22616d6f756e74223a223130303030222c2264656e6f6d223a227374616b65227d2c227472616e736665725f7072696365223a7b22616d6f756e74223a223130303030222c2264656e6f6d223a227374616b65227d7d320e0a057374616b651205313030303012690a500a460a1f2f636f736d6f732e63727970746f2e736563703235366b312e5075624b657912230a2103ca7cd136cf54b73631e5c40850fc78cca77fdcac94ea4abe2768797c8bc7b71312040a020801180212150a0f0a057374616b6512063430303030301080ea301a40ea4a22de567ec67d8210b86636c65a87868ccb6438682c7dae2de8e49fe4b1a763e523c3a5607b9e7a772e2a125e1f7eca27d90c6976f3997834a4ccf5c14ba0"
-	res, err = BroadCastTxAsync(c, log, txInstantiate)
+	txInstantiate := fixtures.Tx("instantiate_nameservice")
+	resInstantiate, err := BroadCastTxAsync(c, log, txInstantiate)
 	if err != nil {
 		log.Fatal("error deploying wasm contract", zap.Error(err))
 	}
@@ -95,7 +134,7 @@ func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex st
 		log.Info("waiting for transaction to be committed")
 
 		<-time.After(5 * time.Second)
-		instantiateResultTx, err := c.Tx(context.Background(), res.Hash, false)
+		instantiateResultTx, err := c.Tx(context.Background(), resInstantiate.Hash, false)
 		if err != nil {
 			log.Fatal("error getting transaction", zap.Error(err))
 			continue
@@ -142,9 +181,18 @@ func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex st
 		break
 	}
 
+	gasReport.Record(c, log, "/cosmwasm.wasm.v1.MsgInstantiateContract", resInstantiate.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, encodedQueryAllBalancesRequestU1, txInstantiate, balanceU1, 10000)
+
+	// subscribe to the custom wasm events of the instantiated contract
+	wasmEventsDone := make(chan struct{})
+	defer close(wasmEventsDone)
+	wasmEventsQuery := fmt.Sprintf("tm.event='Tx' AND wasm._contract_address='%s'", rawContractAddress)
+	wasmEvents := SubscribeTxEvents(c, log, wasmEventsQuery, wasmEventsDone)
+
 	log.Info("executing wasm contract")
-	txExecuteContractHex := "0ac9010ac6010a242f636f736d7761736d2e7761736d2e76312e4d736745786563757465436f6e7472616374129d010a2b7761736d316b6e673673716b6d306d6a7568303963777a367538366637356c6d65666c6a39683066716872123f7761736d3134686a32746176713866706573647778786375343472747933686839307668756a7276636d73746c347a723374786d66767739733070686734641a1c7b227265676973746572223a7b226e616d65223a2263696474227d7d2a0f0a057374616b65120631303030303012690a500a460a1f2f636f736d6f732e63727970746f2e736563703235366b312e5075624b657912230a2103ca7cd136cf54b73631e5c40850fc78cca77fdcac94ea4abe2768797c8bc7b71312040a020801180312150a0f0a057374616b6512063430303030301080ea301a406090971f7ed976cc802eae5942f2c505ded4cf5648f73716eaeb104b69b9153f2b880a76cf270e196fd070fec72f6f97939fe9e12056d3552e9ee5ab32709bd1"
-	res, err = BroadCastTxAsync(c, log, txExecuteContractHex)
+	txExecuteContractHex := fixtures.Tx("register_cidt")
+	resExecute, err := BroadCastTxAsync(c, log, txExecuteContractHex)
 	if err != nil {
 		log.Fatal("error deploying wasm contract", zap.Error(err))
 	}
@@ -154,7 +202,7 @@ func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex st
 		log.Info("waiting for transaction to be committed")
 
 		<-time.After(5 * time.Second)
-		execResultTx, err := c.Tx(context.Background(), res.Hash, false)
+		execResultTx, err := c.Tx(context.Background(), resExecute.Hash, false)
 		if err != nil {
 			log.Fatal("error getting transaction", zap.Error(err))
 			continue
@@ -169,8 +217,46 @@ func RunWASMTests(rpcAddrs []string, log logging.Logger, nameserviceDeployHex st
 		break
 	}
 
-	encodedQueryAllBalancesRequestU2 = "0a3f7761736d3134686a32746176713866706573647778786375343472747933686839307668756a7276636d73746c347a723374786d667677397330706867346412247b227265736f6c76655f7265636f7264223a207b226e616d65223a202263696474227d7d"
-	QuerySmartContractStateRequest(c, log, rawContractAddress, encodedQueryAllBalancesRequestU2)
+	CheckTxDelivered(log, wasmEvents, wasmEventsQuery, resExecute.Hash)
+
+	gasReport.Record(c, log, "/cosmwasm.wasm.v1.MsgExecuteContract", resExecute.Hash)
+	balanceU1 = CheckFeeDeduction(c, log, addressU1, encodedQueryAllBalancesRequestU1, txExecuteContractHex, balanceU1, 100000)
+
+	// the fee of a tx which runs out of gas is still deducted, the funds are not sent
+	log.Info("executing wasm contract with too little gas")
+	txOutOfGas := fixtures.Tx("register_out_of_gas")
+	resOutOfGas, err := BroadCastTxAsync(c, log, txOutOfGas)
+	if err != nil {
+		return
+	}
+	outOfGasResult, err := WaitTxFailed(c, log, resOutOfGas.Hash, "sdk")
+	if err != nil {
+		return
+	}
+	if err := CheckOutOfGas(log, outOfGasResult); err != nil {
+		return
+	}
+	CheckFeeDeduction(c, log, addressU1, encodedQueryAllBalancesRequestU1, txOutOfGas, balanceU1, 0)
+
+	QuerySmartContractStateRequest(c, log, rawContractAddress, fixtures.Query("resolve_cidt"))
+
+	RunIndexerTests(
+		c,
+		log,
+		addressU1,
+		addressU2,
+		rawContractAddress,
+		resSend.Hash,
+		resStore.Hash,
+		resInstantiate.Hash,
+		resExecute.Hash,
+	)
+
+	RunMultisigTests(c, log, fixtures, gasReport)
+	RunAuthzTests(c, log, fixtures, gasReport)
+	RunGovTests(c, log, fixtures, genesisParams.VotingPeriod, gasReport)
+	RunStakingTests(c, log, fixtures, genesisParams.UnbondingTime, gasReport)
+	gasReport.Write(log, gasReportPath)
 }
 
 // BroadCastTxAsync - broadcast transaction async
@@ -194,3 +280,118 @@ func BroadCastTxAsync(c *rpchttp.HTTP, log logging.Logger, txHex string) (*coret
 
 	return res, nil
 }
+
+// BroadcastTxRejected broadcasts the transaction and checks CheckTx rejects it with the error of the codespace
+func BroadcastTxRejected(c *rpchttp.HTTP, log logging.Logger, txHex, codespace string) error {
+	txBytes, err := hex.DecodeString(txHex)
+	if err != nil {
+		log.Fatal("error decoding hex", zap.Error(err))
+		return err
+	}
+
+	res, err := c.BroadcastTxSync(context.Background(), txBytes)
+	if err != nil {
+		log.Fatal("BroadcastTxSync error", zap.Error(err))
+		return err
+	}
+	if res.Code == 0 || res.Codespace != codespace {
+		log.Fatal("transaction not rejected",
+			zap.Uint32("code", res.Code),
+			zap.String("codespace", res.Codespace),
+			zap.String("expectedCodespace", codespace),
+		)
+		return errors.New("transaction not rejected")
+	}
+
+	log.Info("Success! transaction rejected", zap.String("codespace", res.Codespace), zap.String("log", res.Log))
+	return nil
+}
+
+// WaitTxCommitted polls the transaction until it is committed and checks it succeeded
+func WaitTxCommitted(c *rpchttp.HTTP, log logging.Logger, hash []byte) (*coretypes.ResultTx, error) {
+	res, err := waitTx(c, log, hash)
+	if err != nil {
+		return nil, err
+	}
+	if res.TxResult.Code != 0 {
+		log.Fatal("transaction failed", zap.Uint32("code", res.TxResult.Code), zap.String("log", res.TxResult.Log))
+		return nil, fmt.Errorf("transaction failed with code %d", res.TxResult.Code)
+	}
+
+	log.Info("Success! transaction committed", zap.Int64("height", res.Height))
+	return res, nil
+}
+
+// WaitTxFailed polls the transaction until it is committed and checks its messages failed
+// with the error of the codespace, the fee is still charged
+func WaitTxFailed(c *rpchttp.HTTP, log logging.Logger, hash []byte, codespace string) (*coretypes.ResultTx, error) {
+	res, err := waitTx(c, log, hash)
+	if err != nil {
+		return nil, err
+	}
+	if res.TxResult.Code == 0 || res.TxResult.Codespace != codespace {
+		log.Fatal("transaction did not fail",
+			zap.Uint32("code", res.TxResult.Code),
+			zap.String("codespace", res.TxResult.Codespace),
+			zap.String("expectedCodespace", codespace),
+		)
+		return nil, errors.New("transaction did not fail")
+	}
+
+	log.Info("Success! transaction failed as expected", zap.Int64("height", res.Height), zap.String("log", res.TxResult.Log))
+	return res, nil
+}
+
+// CheckMsgEvents checks the attribute of the events of the type emitted by every message,
+// the event of the message i has msg_index i and the attribute value want[i].
+// Events without msg_index, like the fee transfer, are ignored.
+func CheckMsgEvents(res *coretypes.ResultTx, eventType, key string, want []string) error {
+	got := make(map[string][]string, len(want))
+	for _, event := range res.TxResult.GetEvents() {
+		if event.Type != eventType {
+			continue
+		}
+		var index, value string
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case "msg_index":
+				index = attr.Value
+			case key:
+				value = attr.Value
+			}
+		}
+		if index != "" {
+			got[index] = append(got[index], value)
+		}
+	}
+
+	if len(got) != len(want) {
+		return fmt.Errorf("%s events of %d messages, expected %d: %v", eventType, len(got), len(want), got)
+	}
+	for i, value := range want {
+		values := got[strconv.Itoa(i)]
+		if len(values) != 1 || values[0] != value {
+			return fmt.Errorf("%s %s of message %d is %v, expected %s", eventType, key, i, values, value)
+		}
+	}
+	return nil
+}
+
+// waitTx polls the transaction until it is committed
+func waitTx(c *rpchttp.HTTP, log logging.Logger, hash []byte) (*coretypes.ResultTx, error) {
+	var lastErr error
+	for i := 0; i < 30; i++ {
+		log.Info("waiting for transaction to be committed")
+
+		<-time.After(5 * time.Second)
+		res, err := c.Tx(context.Background(), hash, false)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return res, nil
+	}
+
+	log.Fatal("transaction not committed", zap.Error(lastErr))
+	return nil, fmt.Errorf("transaction not committed: %w", lastErr)
+}
@@ -0,0 +1,432 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+)
+
+const contractUsage = `Usage: %s contract <command> <address> [args] [flags]
+
+Commands:
+  query <address> <json>               query the contract with the json message
+  execute <address> <json>             execute the contract with the json message and -funds
+  info <address>                       print the code ID, creator, admin and label of the contract
+  state raw <address> <key>            print the value of the key of the contract store, -hex for a hex encoded key
+  state all <address>                  print a page of the keys and values of the contract store
+  history <address>                    print a page of the code history of the contract
+  migrate <address> <code_id> <json>   migrate the contract to the stored code with the json message
+  upgrade <address> <artifact> <json>  upload the artifact, migrate the contract to it and check its code ID
+  update-admin <address> <admin>       set the new admin of the contract
+  clear-admin <address>                remove the admin, the contract can not be migrated anymore
+
+`
+
+// contractArgs - number of arguments of the contract commands
+var contractArgs = map[string]int{
+	"query":        2,
+	"execute":      2,
+	"info":         1,
+	"state raw":    2,
+	"state all":    1,
+	"history":      1,
+	"migrate":      3,
+	"upgrade":      3,
+	"update-admin": 2,
+	"clear-admin":  1,
+}
+
+// contractCommand - chain service and flags of a contract command
+type contractCommand struct {
+	log      *zap.Logger
+	service  *chainclient.ChainService
+	deployer *chainclient.ContractDeployer
+	signer   string
+	funds    sdk.Coins
+	hexKey   bool
+	page     *query.PageRequest
+}
+
+// runContract runs the contract subcommand
+func runContract(args []string) error {
+	fs := flag.NewFlagSet("contract", flag.ExitOnError)
+	envFile := fs.String("env", "", "optional .env file with the chain settings")
+	rpcAddr := fs.String("rpc", "", "RPC address, defaults to RPC_ADDR or the local node address of -blockchain-id")
+	blockchainID := fs.String("blockchain-id", "", "blockchain ID of the local node")
+	chainID := fs.String("chain-id", "", "chain ID, defaults to CHAIN_ID")
+	prefix := fs.String("prefix", "", "account address prefix, defaults to PUB_ADDRESS_PREFIX")
+	denom := fs.String("denom", "", "gas denom, defaults to GAS_DENOM")
+	mnemonic := fs.String("mnemonic", "", "mnemonic of the signer of the transactions, defaults to USER1_MNEMONIC")
+	key := fs.String("key", "", "name of the keyring key signing the transactions instead of the mnemonic")
+	keyringBackend, keyringDir := keyringFlags(fs)
+	funds := fs.String("funds", "", "coins sent to the contract by execute, e.g. 1000stake")
+	hexKey := fs.Bool("hex", false, "the key of state raw is hex encoded")
+	limit := fs.Uint64("limit", 100, "page size of state all and history")
+	pageKey := fs.String("page-key", "", "base64 next_key of the previous page of state all and history")
+	reverse := fs.Bool("reverse", false, "list state all and history in descending order")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), contractUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if err := checkContractArgs(positional); err != nil {
+		return err
+	}
+
+	log := newLogger()
+	defer log.Sync() // flushes buffer, if any
+
+	if *envFile != "" {
+		if err := godotenv.Load(*envFile); err != nil {
+			return fmt.Errorf("error loading env file: %w", err)
+		}
+	}
+
+	addr := flagOrEnv(*rpcAddr, "RPC_ADDR", "")
+	if addr == "" {
+		if *blockchainID == "" {
+			return errors.New("either -rpc, RPC_ADDR or -blockchain-id is required")
+		}
+		addr = "http://127.0.0.1:9750/ext/bc/" + *blockchainID + "/rpc"
+	}
+	c, err := rpchttp.New(addr, "/websocket")
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	var (
+		addrPrefix  = flagOrEnv(*prefix, "PUB_ADDRESS_PREFIX", chainclient.DefaultPrefix)
+		chainIDName = flagOrEnv(*chainID, "CHAIN_ID", chainclient.DefaultChainID)
+		gasDenom    = flagOrEnv(*denom, "GAS_DENOM", chainclient.DefaultDenom)
+		client      *chainclient.ChainClient
+	)
+	if *key != "" {
+		// the file keyring prompts for the password on stdin
+		kr, err := chainclient.NewKeyringWithBackend(*keyringBackend, *keyringDir, os.Stdin)
+		if err != nil {
+			return fmt.Errorf("error opening keyring: %w", err)
+		}
+		client = chainclient.NewChainClientWithKeyring(300000, addrPrefix, chainIDName, gasDenom, kr, log)
+	} else {
+		client = chainclient.NewChainClient(300000, addrPrefix, chainIDName, gasDenom, log)
+	}
+	setGasPrices(log, client)
+
+	cmd := &contractCommand{
+		log:     log,
+		service: chainclient.NewChainService(client, c, log),
+		hexKey:  *hexKey,
+		page:    &query.PageRequest{Limit: *limit, Reverse: *reverse},
+	}
+	if *pageKey != "" {
+		if cmd.page.Key, err = base64.StdEncoding.DecodeString(*pageKey); err != nil {
+			return fmt.Errorf("invalid page key: %w", err)
+		}
+	}
+	if *funds != "" {
+		if cmd.funds, err = sdk.ParseCoinsNormalized(*funds); err != nil {
+			return fmt.Errorf("invalid funds: %w", err)
+		}
+	}
+
+	command, rest := positional[0], positional[1:]
+	switch command {
+	case "execute", "migrate", "upgrade", "update-admin", "clear-admin":
+		var acc chainclient.AccountInfo
+		if *key != "" {
+			acc = addKeySigner(log, client, *key)
+		} else {
+			acc = addSigner(log, client, 1, *mnemonic)
+		}
+		if err := cmd.service.LoadAccount(acc.Name); err != nil {
+			return fmt.Errorf("error loading signer account: %w", err)
+		}
+		cmd.signer = acc.Name
+		cmd.deployer = chainclient.NewContractDeployer(acc, cmd.service, client, log)
+	}
+
+	switch {
+	case command == "query" && len(rest) == 2:
+		return cmd.query(rest[0], rest[1])
+	case command == "execute" && len(rest) == 2:
+		return cmd.execute(rest[0], rest[1])
+	case command == "info" && len(rest) == 1:
+		return cmd.info(rest[0])
+	case command == "state" && len(rest) == 3 && rest[0] == "raw":
+		return cmd.stateRaw(rest[1], rest[2])
+	case command == "state" && len(rest) == 2 && rest[0] == "all":
+		return cmd.stateAll(rest[1])
+	case command == "history" && len(rest) == 1:
+		return cmd.history(rest[0])
+	case command == "migrate" && len(rest) == 3:
+		return cmd.migrate(rest[0], rest[1], rest[2])
+	case command == "upgrade" && len(rest) == 3:
+		return cmd.upgrade(rest[0], rest[1], rest[2])
+	case command == "update-admin" && len(rest) == 2:
+		return cmd.updateAdmin(rest[0], rest[1])
+	case command == "clear-admin" && len(rest) == 1:
+		return cmd.clearAdmin(rest[0])
+	default:
+		return fmt.Errorf("unknown command or wrong number of arguments: %s", strings.Join(positional, " "))
+	}
+}
+
+// checkContractArgs returns an error if the command is unknown or has a wrong number of arguments
+func checkContractArgs(args []string) error {
+	name, rest := args[0], args[1:]
+	if name == "state" {
+		name, rest = "state "+rest[0], rest[1:]
+	}
+	want, ok := contractArgs[name]
+	if !ok {
+		return fmt.Errorf("unknown contract command %q", name)
+	}
+	if len(rest) != want {
+		return fmt.Errorf("%s takes %d arguments, got %d: %q", name, want, len(rest), rest)
+	}
+	return nil
+}
+
+// parseInterspersed parses the flags before, between and after the positional arguments
+// and returns the positional arguments, the arguments after "--" are not parsed
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if parsed := len(args) - fs.NArg(); parsed > 0 && args[parsed-1] == "--" {
+			return append(positional, fs.Args()...), nil
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// query prints the response of the smart query
+func (cmd *contractCommand) query(address, msg string) error {
+	if !json.Valid([]byte(msg)) {
+		return fmt.Errorf("invalid json query %s", msg)
+	}
+	data, err := cmd.service.QuerySmartContract(address, []byte(msg))
+	if err != nil {
+		return err
+	}
+	return printJSON(json.RawMessage(data))
+}
+
+// execute executes the contract and prints the transaction, the wasm attributes and the returned data
+func (cmd *contractCommand) execute(address, msg string) error {
+	if !json.Valid([]byte(msg)) {
+		return fmt.Errorf("invalid json message %s", msg)
+	}
+	txRes, err := cmd.service.ExecuteContract(cmd.signer, address, []byte(msg), cmd.funds, chainclient.AutoGas)
+	if err != nil {
+		return err
+	}
+	res, err := cmd.service.DecodeTxResult(txRes)
+	if err != nil {
+		return err
+	}
+	executed, err := res.Execute(0)
+	if err != nil {
+		return err
+	}
+
+	type attribute struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	out := struct {
+		TxHash     string      `json:"txhash"`
+		Height     int64       `json:"height"`
+		GasWanted  int64       `json:"gas_wanted"`
+		GasUsed    int64       `json:"gas_used"`
+		Attributes []attribute `json:"attributes"`
+		Data       []byte      `json:"data,omitempty"`
+	}{
+		TxHash:    res.Hash,
+		Height:    txRes.Height,
+		GasWanted: txRes.TxResult.GasWanted,
+		GasUsed:   txRes.TxResult.GasUsed,
+		Data:      executed.Data,
+	}
+	for _, attr := range executed.Attributes {
+		out.Attributes = append(out.Attributes, attribute{Key: attr.Key, Value: attr.Value})
+	}
+	return printJSON(out)
+}
+
+// info prints the contract info
+func (cmd *contractCommand) info(address string) error {
+	info, err := cmd.service.GetContractInfo(address)
+	if err != nil {
+		return err
+	}
+	return cmd.printProto(info)
+}
+
+// stateRaw prints the value of the key of the contract store
+func (cmd *contractCommand) stateRaw(address, key string) error {
+	rawKey := []byte(key)
+	if cmd.hexKey {
+		var err error
+		if rawKey, err = hex.DecodeString(key); err != nil {
+			return fmt.Errorf("invalid hex key: %w", err)
+		}
+	}
+	value, err := cmd.service.QueryRawContractState(address, rawKey)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return fmt.Errorf("key %q is not set", key)
+	}
+	return printJSON(stateValue(value))
+}
+
+// stateAll prints a page of the contract store, the keys are hex encoded
+func (cmd *contractCommand) stateAll(address string) error {
+	res, err := cmd.service.GetAllContractState(address, cmd.page)
+	if err != nil {
+		return err
+	}
+
+	type model struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}
+	out := struct {
+		Models  []model `json:"models"`
+		NextKey []byte  `json:"next_key,omitempty"`
+	}{Models: make([]model, 0, len(res.Models))}
+	for _, m := range res.Models {
+		out.Models = append(out.Models, model{Key: hex.EncodeToString(m.Key), Value: stateValue(m.Value)})
+	}
+	if res.Pagination != nil {
+		out.NextKey = res.Pagination.NextKey
+	}
+	return printJSON(out)
+}
+
+// history prints a page of the code history of the contract
+func (cmd *contractCommand) history(address string) error {
+	res, err := cmd.service.GetContractHistory(address, cmd.page)
+	if err != nil {
+		return err
+	}
+	return cmd.printProto(res)
+}
+
+// migrate migrates the contract to the code id and prints the transaction and the returned data
+func (cmd *contractCommand) migrate(address, rawCodeID, msg string) error {
+	codeID, err := strconv.ParseUint(rawCodeID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid code id: %w", err)
+	}
+	if !json.Valid([]byte(msg)) {
+		return fmt.Errorf("invalid json message %s", msg)
+	}
+	txRes, err := cmd.service.MigrateContract(cmd.signer, address, codeID, []byte(msg), chainclient.AutoGas)
+	if err != nil {
+		return err
+	}
+	res, err := cmd.service.DecodeTxResult(txRes)
+	if err != nil {
+		return err
+	}
+	migrated, err := res.Migrate(0)
+	if err != nil {
+		return err
+	}
+	return printJSON(struct {
+		TxHash string `json:"txhash"`
+		CodeID uint64 `json:"code_id"`
+		Data   []byte `json:"data,omitempty"`
+	}{res.Hash, migrated.CodeID, migrated.Data})
+}
+
+// upgrade uploads the artifact and migrates the contract to the new code
+func (cmd *contractCommand) upgrade(address, artifact, msg string) error {
+	if !json.Valid([]byte(msg)) {
+		return fmt.Errorf("invalid json message %s", msg)
+	}
+	upgraded, err := cmd.deployer.Upgrade(address, artifact, []byte(msg), chainclient.AutoGas)
+	if err != nil {
+		return err
+	}
+	return printJSON(struct {
+		CodeID        uint64 `json:"code_id"`
+		StoreTxHash   string `json:"store_txhash"`
+		MigrateTxHash string `json:"migrate_txhash"`
+		Data          []byte `json:"data,omitempty"`
+	}{upgraded.CodeID, upgraded.StoreTxHash, upgraded.MigrateTxHash, upgraded.Data})
+}
+
+// updateAdmin sets the new admin and prints the contract info
+func (cmd *contractCommand) updateAdmin(address, admin string) error {
+	if _, err := cmd.service.UpdateAdmin(cmd.signer, address, admin, chainclient.AutoGas); err != nil {
+		return err
+	}
+	return cmd.info(address)
+}
+
+// clearAdmin removes the admin and prints the contract info
+func (cmd *contractCommand) clearAdmin(address string) error {
+	if _, err := cmd.service.ClearAdmin(cmd.signer, address, chainclient.AutoGas); err != nil {
+		return err
+	}
+	return cmd.info(address)
+}
+
+// printProto prints the message as proto JSON
+func (cmd *contractCommand) printProto(msg gogoproto.Message) error {
+	out, err := cmd.service.Client().Codec.GetMarshaler().MarshalJSON(msg)
+	if err != nil {
+		return err
+	}
+	return printJSON(json.RawMessage(out))
+}
+
+// stateValue returns the JSON values of the contract store as is and the other values as strings
+func stateValue(value []byte) any {
+	if json.Valid(value) {
+		return json.RawMessage(value)
+	}
+	return string(value)
+}
+
+// printJSON prints the value as indented JSON to stdout, the logs go to stderr
+func printJSON(v any) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+)
+
+const keysUsage = `Usage: %s keys [flags] <command> [name]
+
+Commands:
+  add <name>       create a key with a new mnemonic or, with -multisig, the multisig key of the listed keys
+  import <name>    import a key from the mnemonic or, with -armor, the armored private key read from stdin
+  export <name>    print the private key armored with the passphrase read from stdin
+  list             list the keys
+  delete <name>    delete the key
+
+`
+
+// keyringFlags registers the keyring backend and directory flags
+func keyringFlags(fs *flag.FlagSet) (backend, dir *string) {
+	backend = fs.String("keyring-backend", keyring.BackendTest, "keyring backend: os, file or test")
+	dir = fs.String("keyring-dir", chainclient.DefaultKeyringDir(), "directory of the file and test keyrings")
+	return backend, dir
+}
+
+// runKeys runs the keys subcommand
+func runKeys(args []string) error {
+	fs := flag.NewFlagSet("keys", flag.ExitOnError)
+	backend, dir := keyringFlags(fs)
+	prefix := fs.String("prefix", "", "account address prefix, defaults to PUB_ADDRESS_PREFIX")
+	armor := fs.Bool("armor", false, "import the armored private key instead of the mnemonic")
+	keyType := fs.String("key-type", string(chainclient.KeyTypeSecp256k1), "key type: secp256k1, eth_secp256k1 or ed25519")
+	hdPath := fs.String("hd-path", "", "HD derivation path, defaults to the path of the key type")
+	multisigKeys := fs.String("multisig", "", "comma separated keys of the multisig key created by add")
+	threshold := fs.Int("threshold", 1, "number of signatures required by the multisig key")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), keysUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	if err := chainclient.SetPrefixes(flagOrEnv(*prefix, "PUB_ADDRESS_PREFIX", chainclient.DefaultPrefix)); err != nil {
+		return err
+	}
+
+	// the keyring prompts and the command read from the same buffered stdin
+	stdin := bufio.NewReader(os.Stdin)
+	kr, err := chainclient.NewKeyringWithBackend(*backend, *dir, stdin)
+	if err != nil {
+		return err
+	}
+
+	algo, path, err := chainclient.SigningAlgo(chainclient.AccountOptions{
+		KeyType: chainclient.KeyType(*keyType),
+		HDPath:  *hdPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	command, name := fs.Arg(0), fs.Arg(1)
+	if command != "list" && name == "" {
+		return fmt.Errorf("keys %s requires the key name", command)
+	}
+
+	switch command {
+	case "add":
+		if *multisigKeys != "" {
+			pubKey, err := chainclient.MultisigPubKey(kr, *threshold, strings.Split(*multisigKeys, ","))
+			if err != nil {
+				return err
+			}
+			record, err := kr.SaveMultisig(name, pubKey)
+			if err != nil {
+				return err
+			}
+			return printKey(record)
+		}
+
+		record, mnemonic, err := kr.NewMnemonic(name, keyring.English, path, keyring.DefaultBIP39Passphrase, algo)
+		if err != nil {
+			return err
+		}
+		if err := printKey(record); err != nil {
+			return err
+		}
+		fmt.Printf("\nWrite down the mnemonic, it is the only way to recover the key:\n\n%s\n", mnemonic)
+	case "import":
+		if *armor {
+			fmt.Fprintln(os.Stderr, "Enter the armored private key, then the passphrase:")
+			armored, err := readArmor(stdin)
+			if err != nil {
+				return err
+			}
+			passphrase, err := readLine(stdin)
+			if err != nil {
+				return err
+			}
+			if err := kr.ImportPrivKey(name, armored, passphrase); err != nil {
+				return err
+			}
+		} else {
+			fmt.Fprintln(os.Stderr, "Enter the mnemonic:")
+			mnemonic, err := readLine(stdin)
+			if err != nil {
+				return err
+			}
+			if _, err := kr.NewAccount(name, mnemonic, keyring.DefaultBIP39Passphrase, path, algo); err != nil {
+				return err
+			}
+		}
+		record, err := kr.Key(name)
+		if err != nil {
+			return err
+		}
+		return printKey(record)
+	case "export":
+		fmt.Fprintln(os.Stderr, "Enter the passphrase to encrypt the exported key:")
+		passphrase, err := readLine(stdin)
+		if err != nil {
+			return err
+		}
+		armored, err := kr.ExportPrivKeyArmor(name, passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Println(armored)
+	case "list":
+		records, err := kr.List()
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if err := printKey(record); err != nil {
+				return err
+			}
+		}
+	case "delete":
+		if err := kr.Delete(name); err != nil {
+			return err
+		}
+		fmt.Printf("key %s deleted\n", name)
+	default:
+		return fmt.Errorf("unknown keys command %q", command)
+	}
+
+	return nil
+}
+
+// printKey prints the name and the address of the key
+func printKey(record *keyring.Record) error {
+	addr, err := record.GetAddress()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\t%s\n", record.Name, addr.String())
+	return nil
+}
+
+// readLine reads a non empty line from the reader
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		if err != nil {
+			return "", fmt.Errorf("error reading input: %w", err)
+		}
+		return "", errors.New("empty input")
+	}
+	return line, nil
+}
+
+// readArmor reads the armored key up to the END line
+func readArmor(r *bufio.Reader) (string, error) {
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" || len(lines) > 0 {
+			lines = append(lines, line)
+		}
+		if strings.HasPrefix(line, "-----END") {
+			return strings.Join(lines, "\n"), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("error reading armored key: %w", err)
+		}
+	}
+}
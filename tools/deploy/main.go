@@ -0,0 +1,283 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+)
+
+// testMnemonics are the default mnemonics of the signers, USER<N>_MNEMONIC overrides them
+var testMnemonics = []string{chainclient.User1Mnemonic, chainclient.User2Mnemonic}
+
+// varsFlag collects repeated -var name=value flags
+type varsFlag map[string]string
+
+func (v varsFlag) String() string {
+	return fmt.Sprint(map[string]string(v))
+}
+
+func (v varsFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", s)
+	}
+	v[name] = value
+	return nil
+}
+
+// listFlag collects repeated flag values
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "keys" {
+		if err := runKeys(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "contract" {
+		if err := runContract(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var (
+		manifestPath = flag.String("manifest", "", "YAML or JSON deployment manifest")
+		envFile      = flag.String("env", "", "optional .env file with the chain settings")
+		rpcAddr      = flag.String("rpc", "", "RPC address, defaults to RPC_ADDR or the local node address of the blockchain ID")
+		chainID      = flag.String("chain-id", "", "chain ID, defaults to CHAIN_ID")
+		prefix       = flag.String("prefix", "", "account address prefix, defaults to PUB_ADDRESS_PREFIX")
+		denom        = flag.String("denom", "", "gas denom, defaults to GAS_DENOM")
+		mnemonic     = flag.String("mnemonic", "", "mnemonic of the first signer, defaults to USER1_MNEMONIC")
+		signers      = flag.Int("signers", 1, "number of signer accounts deploying independent contracts in parallel, "+
+			"the signer N uses USER<N>_MNEMONIC")
+		batch      = flag.Bool("batch", false, "store and instantiate every contract in one transaction if it fits, requires a single signer")
+		maxTxBytes = flag.Int("max-tx-bytes", chainclient.DefaultMaxTxBytes, "max size of a batched transaction")
+		statePath  = flag.String("state", "", "deployment state file, defaults to <manifest>.state.json next to the manifest")
+		vars       = varsFlag{}
+		force      listFlag
+		keys       listFlag
+	)
+	keyringBackend, keyringDir := keyringFlags(flag.CommandLine)
+	flag.Var(&keys, "key", "name of the keyring key signing the deployment instead of the mnemonics, "+
+		"can be repeated to deploy in parallel with several keys")
+	flag.Var(vars, "var", "manifest variable name=value, overrides the manifest vars, can be repeated")
+	flag.Var(&force, "force", "redeploy the contract even if it is recorded in the state file, can be repeated")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -manifest deploy.yaml [flags] [blockchainID]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s keys [flags] <command> [name]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s contract <command> <address> [args] [flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	log := newLogger()
+	defer log.Sync() // flushes buffer, if any
+
+	if *manifestPath == "" || *signers < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *envFile != "" {
+		if err := godotenv.Load(*envFile); err != nil {
+			log.Fatal("error loading env file", zap.Error(err))
+		}
+	}
+
+	manifest, err := chainclient.LoadManifest(*manifestPath)
+	if err != nil {
+		log.Fatal("error loading manifest", zap.Error(err))
+	}
+	if manifest.Vars == nil {
+		manifest.Vars = make(map[string]string, len(vars))
+	}
+	for name, value := range vars {
+		manifest.Vars[name] = value
+	}
+
+	if *statePath == "" {
+		*statePath = strings.TrimSuffix(*manifestPath, filepath.Ext(*manifestPath)) + ".state.json"
+	}
+	state, err := chainclient.LoadDeployState(*statePath)
+	if err != nil {
+		log.Fatal("error loading deployment state", zap.Error(err))
+	}
+
+	addr := flagOrEnv(*rpcAddr, "RPC_ADDR", "")
+	if addr == "" {
+		if flag.NArg() == 0 {
+			log.Fatal("either -rpc, RPC_ADDR or the blockchain ID is required")
+		}
+		addr = "http://127.0.0.1:9750/ext/bc/" + flag.Arg(0) + "/rpc"
+	}
+
+	c, err := rpchttp.New(addr, "/websocket")
+	if err != nil {
+		log.Fatal("error creating client", zap.Error(err)) //nolint:gocritic
+	}
+
+	var (
+		addrPrefix  = flagOrEnv(*prefix, "PUB_ADDRESS_PREFIX", chainclient.DefaultPrefix)
+		chainIDName = flagOrEnv(*chainID, "CHAIN_ID", chainclient.DefaultChainID)
+		gasDenom    = flagOrEnv(*denom, "GAS_DENOM", chainclient.DefaultDenom)
+		client      *chainclient.ChainClient
+	)
+	if len(keys) > 0 {
+		// the file keyring prompts for the password on stdin
+		kr, err := chainclient.NewKeyringWithBackend(*keyringBackend, *keyringDir, os.Stdin)
+		if err != nil {
+			log.Fatal("error opening keyring", zap.Error(err))
+		}
+		client = chainclient.NewChainClientWithKeyring(300000, addrPrefix, chainIDName, gasDenom, kr, log)
+	} else {
+		client = chainclient.NewChainClient(300000, addrPrefix, chainIDName, gasDenom, log)
+	}
+
+	setGasPrices(log, client)
+
+	chainService := chainclient.NewChainService(client, c, log)
+	chainService.SetMaxTxBytes(*maxTxBytes)
+
+	if len(keys) > 0 {
+		*signers = len(keys)
+	}
+	deployers := make([]*chainclient.ContractDeployer, *signers)
+	for i := range deployers {
+		var acc chainclient.AccountInfo
+		if len(keys) > 0 {
+			acc = addKeySigner(log, client, keys[i])
+		} else {
+			acc = addSigner(log, client, i+1, *mnemonic)
+		}
+		if err := chainService.LoadAccount(acc.Name); err != nil {
+			log.Fatal("error loading signer account", zap.Error(err))
+		}
+		deployers[i] = chainclient.NewContractDeployer(acc, chainService, client, log)
+	}
+
+	deployed, err := chainclient.DeployManifestParallel(manifest, deployers, chainclient.DeployOptions{
+		GasPrice: chainclient.AutoGas,
+		State:    state,
+		Force:    force,
+		Batch:    *batch,
+	})
+	if err != nil {
+		log.Fatal("error deploying manifest", zap.Error(err))
+	}
+
+	names := make([]string, 0, len(deployed))
+	for name := range deployed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		log.Info("deployed",
+			zap.String("name", name),
+			zap.Uint64("code_id", deployed[name].CodeID),
+			zap.String("address", deployed[name].Address),
+		)
+	}
+	log.Info("All contracts deployed successfully", zap.String("manifest", *manifestPath), zap.String("state", *statePath))
+}
+
+// newLogger configures the zap logger without time and caller information
+func newLogger() *zap.Logger {
+	config := zap.NewProductionConfig()
+	config.EncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout("")
+	config.EncoderConfig.CallerKey = "" // Remove caller information
+	log, err := config.Build()
+	if err != nil {
+		panic(fmt.Sprintf("can't initialize zap logger: %v", err))
+	}
+	return log
+}
+
+// setGasPrices sets the gas adjustment and the min gas price used when the gas is estimated by simulation
+func setGasPrices(log *zap.Logger, client *chainclient.ChainClient) {
+	gasAdjustment, err := strconv.ParseFloat(getEnv("GAS_ADJUSTMENT", "1.3"), 64)
+	if err != nil {
+		log.Fatal("invalid GAS_ADJUSTMENT", zap.Error(err))
+	}
+	if err := client.SetGasPrices(gasAdjustment, getEnv("MIN_GAS_PRICE", "0.5"+client.GetDenom())); err != nil {
+		log.Fatal("error setting gas prices", zap.Error(err))
+	}
+}
+
+// addSigner adds the signer account number n, the first signer may be set by the flag
+func addSigner(log *zap.Logger, client *chainclient.ChainClient, n int, mnemonic string) chainclient.AccountInfo {
+	name := fmt.Sprintf("user%d", n)
+
+	var fallback string
+	if n <= len(testMnemonics) {
+		fallback = testMnemonics[n-1]
+	}
+	if n > 1 {
+		mnemonic = ""
+	}
+
+	mnemonic = flagOrEnv(mnemonic, fmt.Sprintf("USER%d_MNEMONIC", n), fallback)
+	if mnemonic == "" {
+		log.Fatal("signer mnemonic is not set", zap.String("env", fmt.Sprintf("USER%d_MNEMONIC", n)))
+	}
+
+	client.AddAccount(name, mnemonic)
+	acc, exist := client.GetAccount(name)
+	if !exist {
+		log.Fatal("account not found", zap.String("signer", name))
+	}
+	log.Info("account address", zap.String(name, acc.Address))
+	return acc
+}
+
+// addKeySigner adds the signer account of the keyring key
+func addKeySigner(log *zap.Logger, client *chainclient.ChainClient, name string) chainclient.AccountInfo {
+	if err := client.AddKey(name); err != nil {
+		log.Fatal("error adding signer key", zap.Error(err))
+	}
+	acc, exist := client.GetAccount(name)
+	if !exist {
+		log.Fatal("account not found", zap.String("signer", name))
+	}
+	log.Info("account address", zap.String(name, acc.Address))
+	return acc
+}
+
+// flagOrEnv returns the flag value if it is set, otherwise the environment variable or the fallback value
+func flagOrEnv(value, key, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return getEnv(key, fallback)
+}
+
+// getEnv returns the environment variable or the fallback value if it is not set
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+	icacontrollertypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"go.uber.org/zap"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+	"github.com/consideritdone/landslide-runner/chainclient/relayer"
+)
+
+// icaFunds - tokens sent to the interchain account on the host chain
+const icaFunds = 1000000
+
+// icaSendAmount - tokens the interchain account sends back to user1 on the host chain
+const icaSendAmount = 400000
+
+// runICATests registers the interchain account of user1 of the chain A on the chain B,
+// funds it on B and sends the tokens of the account by a transaction executed over the channel.
+// The registration opens the channel on A, the relayer completes the handshake.
+func runICATests(log *zap.Logger, path *relayer.Path) error {
+	a, b := path.EndpointA, path.EndpointB
+	serviceA, serviceB := a.Chain.Service(), b.Chain.Service()
+	denom := serviceB.Client().GetDenom()
+	user1A, _ := serviceA.Client().GetAccount("user1")
+	user1B, _ := serviceB.Client().GetAccount("user1")
+
+	log.Info("Registering the interchain account of user1")
+	msgRegister := icacontrollertypes.NewMsgRegisterInterchainAccount(a.ConnectionID, user1A.Address, "")
+	res, err := serviceA.SendMsgs("user1", []sdk.Msg{msgRegister}, chainclient.AutoGas)
+	if err != nil {
+		return err
+	}
+	a.PortID, err = relayer.EventAttribute(res.TxResult.Events, channeltypes.EventTypeChannelOpenInit, channeltypes.AttributeKeyPortID)
+	if err != nil {
+		return err
+	}
+	a.ChannelID, err = relayer.EventAttribute(res.TxResult.Events, channeltypes.EventTypeChannelOpenInit, channeltypes.AttributeKeyChannelID)
+	if err != nil {
+		return err
+	}
+	b.PortID = icatypes.HostPortID
+	if err := path.CompleteChannelHandshake(); err != nil {
+		return err
+	}
+
+	resAccount := &icacontrollertypes.QueryInterchainAccountResponse{}
+	err = serviceA.Query(
+		"/ibc.applications.interchain_accounts.controller.v1.Query/InterchainAccount",
+		&icacontrollertypes.QueryInterchainAccountRequest{Owner: user1A.Address, ConnectionId: a.ConnectionID},
+		resAccount,
+	)
+	if err != nil {
+		return fmt.Errorf("error querying interchain account: %w", err)
+	}
+	icaAddress := resAccount.Address
+	log.Info("interchain account registered", zap.String("address", icaAddress))
+
+	log.Info("Funding the interchain account")
+	msgFund := banktypes.NewMsgSend(
+		sdk.MustAccAddressFromBech32(user1B.Address),
+		sdk.MustAccAddressFromBech32(icaAddress),
+		sdk.NewCoins(sdk.NewInt64Coin(denom, icaFunds)),
+	)
+	if _, err := serviceB.SendMsgs("user1", []sdk.Msg{msgFund}, chainclient.AutoGas); err != nil {
+		return err
+	}
+	if err := checkBalance(log, serviceB, icaAddress, denom, icaFunds); err != nil {
+		return err
+	}
+
+	log.Info("Sending tokens of the interchain account by user1")
+	msgSend := banktypes.NewMsgSend(
+		sdk.MustAccAddressFromBech32(icaAddress),
+		sdk.MustAccAddressFromBech32(user1B.Address),
+		sdk.NewCoins(sdk.NewInt64Coin(denom, icaSendAmount)),
+	)
+	data, err := icatypes.SerializeCosmosTx(serviceA.Client().Codec.GetMarshaler(), []proto.Message{msgSend}, icatypes.EncodingProtobuf)
+	if err != nil {
+		return err
+	}
+	packetData := icatypes.InterchainAccountPacketData{Type: icatypes.EXECUTE_TX, Data: data}
+	msgSendTx := icacontrollertypes.NewMsgSendTx(user1A.Address, a.ConnectionID, uint64(packetTimeout.Nanoseconds()), packetData)
+	if err := sendAndRelay(a, "user1", msgSendTx); err != nil {
+		return err
+	}
+	if err := checkBalance(log, serviceB, icaAddress, denom, icaFunds-icaSendAmount); err != nil {
+		return err
+	}
+
+	log.Info("Success! interchain account passed")
+	return nil
+}
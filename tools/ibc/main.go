@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	sdkmath "cosmossdk.io/math"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+	"github.com/consideritdone/landslide-runner/chainclient/relayer"
+)
+
+// DefaultChainIDB - chain ID of the second landslide test chain
+const DefaultChainIDB = "landslide-ibc"
+
+// relayerAccount - account signing the relayer messages on both chains
+const relayerAccount = "user2"
+
+func main() {
+	var (
+		rpcA     = flag.String("rpc-a", "", "RPC address of the chain A")
+		rpcB     = flag.String("rpc-b", "", "RPC address of the chain B")
+		chainIDA = flag.String("chain-id-a", chainclient.DefaultChainID, "chain ID of the chain A")
+		chainIDB = flag.String("chain-id-b", DefaultChainIDB, "chain ID of the chain B")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -rpc-a <rpc> -rpc-b <rpc> [flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Configure zap logger
+	config := zap.NewProductionConfig()
+	config.EncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout("")
+	config.EncoderConfig.CallerKey = "" // Remove caller information
+	log, err := config.Build()
+	if err != nil {
+		panic(fmt.Sprintf("can't initialize zap logger: %v", err))
+	}
+	defer log.Sync() // flushes buffer, if any
+
+	if *rpcA == "" || *rpcB == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	chainA := newChain(log, *rpcA, *chainIDA)
+	chainB := newChain(log, *rpcB, *chainIDB)
+
+	// clients, connection and the transfer channel
+	path := relayer.NewPath(chainA, chainB)
+	if err := path.Setup(); err != nil {
+		log.Fatal("error connecting the chains", zap.Error(err))
+	}
+	err = path.CreateChannel(transfertypes.PortID, transfertypes.PortID, transfertypes.Version, channeltypes.UNORDERED)
+	if err != nil {
+		log.Fatal("error opening the transfer channel", zap.Error(err))
+	}
+
+	if err := runTransferTests(log, path); err != nil {
+		log.Fatal("transfer tests failed", zap.Error(err))
+	}
+	if err := runICATests(log, path.ConnectionPath()); err != nil {
+		log.Fatal("interchain accounts tests failed", zap.Error(err))
+	}
+
+	log.Info("Success! IBC tests passed")
+}
+
+// newChain creates the chain client with the test accounts and the relayer chain
+func newChain(log *zap.Logger, rpcAddr, chainID string) *relayer.Chain {
+	c, err := rpchttp.New(rpcAddr, "/websocket")
+	if err != nil {
+		log.Fatal("error creating client", zap.String("rpc", rpcAddr), zap.Error(err))
+	}
+
+	client := chainclient.NewChainClient(300000, chainclient.DefaultPrefix, chainID, chainclient.DefaultDenom, log)
+	client.AddAccount("user1", chainclient.User1Mnemonic)
+	client.AddAccount("user2", chainclient.User2Mnemonic)
+
+	chain, err := relayer.NewChain(chainclient.NewChainService(client, c, log), relayerAccount, log)
+	if err != nil {
+		log.Fatal("error creating relayer chain", zap.String("chainID", chainID), zap.Error(err))
+	}
+	return chain
+}
+
+// checkBalance checks the balance of the address in the denom
+func checkBalance(log *zap.Logger, service *chainclient.ChainService, address, denom string, expected int64) error {
+	balances, err := service.GetBalances(address)
+	if err != nil {
+		return err
+	}
+	if balance := balances.AmountOf(denom); !balance.Equal(sdkmath.NewInt(expected)) {
+		return fmt.Errorf("unexpected balance of %s on %s: expected %d%s, got %s%s",
+			address, service.Client().GetChainID(), expected, denom, balance, denom)
+	}
+
+	log.Info("balance check success",
+		zap.String("chainID", service.Client().GetChainID()),
+		zap.String("address", address),
+		zap.String("denom", denom),
+		zap.Int64("balance", expected),
+	)
+	return nil
+}
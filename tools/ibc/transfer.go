@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	"go.uber.org/zap"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+	"github.com/consideritdone/landslide-runner/chainclient/relayer"
+)
+
+// transferAmount - tokens sent to the chain B and back
+const transferAmount = 1000000
+
+// packetTimeout - timeout of the packets after they are sent
+const packetTimeout = 10 * time.Minute
+
+// runTransferTests sends the tokens of user1 from the chain A to the chain B over the transfer channel
+// and back. The tokens are escrowed on A and minted as the vouchers on B, the return burns the vouchers
+// and releases the escrow.
+func runTransferTests(log *zap.Logger, path *relayer.Path) error {
+	a, b := path.EndpointA, path.EndpointB
+	serviceA, serviceB := a.Chain.Service(), b.Chain.Service()
+	denom := serviceA.Client().GetDenom()
+	user1A, _ := serviceA.Client().GetAccount("user1")
+	user1B, _ := serviceB.Client().GetAccount("user1")
+
+	escrow := transfertypes.GetEscrowAddress(a.PortID, a.ChannelID).String()
+	voucher := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(b.PortID, b.ChannelID, denom)).IBCDenom()
+
+	log.Info("Sending tokens of user1 to the chain B", zap.String("voucher", voucher))
+	msg := transfertypes.NewMsgTransfer(
+		a.PortID, a.ChannelID, sdk.NewInt64Coin(denom, transferAmount), user1A.Address, user1B.Address,
+		clienttypes.ZeroHeight(), timeoutTimestamp(), "",
+	)
+	if err := sendAndRelay(a, "user1", msg); err != nil {
+		return err
+	}
+	if err := checkBalance(log, serviceA, escrow, denom, transferAmount); err != nil {
+		return err
+	}
+	if err := checkBalance(log, serviceB, user1B.Address, voucher, transferAmount); err != nil {
+		return err
+	}
+
+	log.Info("Sending the vouchers of user1 back to the chain A")
+	msg = transfertypes.NewMsgTransfer(
+		b.PortID, b.ChannelID, sdk.NewInt64Coin(voucher, transferAmount), user1B.Address, user1A.Address,
+		clienttypes.ZeroHeight(), timeoutTimestamp(), "",
+	)
+	if err := sendAndRelay(b, "user1", msg); err != nil {
+		return err
+	}
+	if err := checkBalance(log, serviceA, escrow, denom, 0); err != nil {
+		return err
+	}
+	if err := checkBalance(log, serviceB, user1B.Address, voucher, 0); err != nil {
+		return err
+	}
+
+	log.Info("Success! transfer round trip passed")
+	return nil
+}
+
+// sendAndRelay sends the message which sends a packet on the chain of the endpoint,
+// relays the packet and checks it is acknowledged with a success
+func sendAndRelay(e *relayer.Endpoint, signerName string, msg sdk.Msg) error {
+	res, err := e.Chain.Service().SendMsgs(signerName, []sdk.Msg{msg}, chainclient.AutoGas)
+	if err != nil {
+		return err
+	}
+	acks, err := e.RelayPackets(res)
+	if err != nil {
+		return err
+	}
+	for _, ack := range acks {
+		if _, err := relayer.CheckAck(ack); err != nil {
+			return fmt.Errorf("%s: %w", sdk.MsgTypeURL(msg), err)
+		}
+	}
+	return nil
+}
+
+// timeoutTimestamp returns the timeout of the packet sent now
+func timeoutTimestamp() uint64 {
+	return uint64(time.Now().Add(packetTimeout).UnixNano())
+}
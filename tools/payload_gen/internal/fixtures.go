@@ -0,0 +1,317 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"go.uber.org/zap"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+)
+
+type (
+	// Fixtures - the fixture bundle loaded by the e2e suite
+	Fixtures struct {
+		Scenario  string                    `json:"scenario"`
+		ChainID   string                    `json:"chain_id"`
+		Accounts  map[string]AccountFixture `json:"accounts"`
+		Contracts map[string]string         `json:"contracts,omitempty"`
+		Txs       map[string]TxFixture      `json:"txs"`
+		Queries   map[string]QueryFixture   `json:"queries,omitempty"`
+	}
+
+	// AccountFixture - address and account number of the signer
+	AccountFixture struct {
+		Address string `json:"address"`
+		Number  uint64 `json:"number"`
+	}
+
+	// TxFixture - hex encoded signed transaction, the genesis transactions have the signed JSON too
+	TxFixture struct {
+		Signer   string          `json:"signer"`
+		Sequence uint64          `json:"sequence"`
+		MsgTypes []string        `json:"msg_types"`
+		Hex      string          `json:"hex"`
+		JSON     json.RawMessage `json:"json,omitempty"`
+	}
+
+	// QueryFixture - hex encoded ABCI query request
+	QueryFixture struct {
+		Path string `json:"path"`
+		Hex  string `json:"hex"`
+	}
+)
+
+// Generate - sign the transactions and encode the queries of the scenario
+func Generate(s *Scenario, name string, log *zap.Logger) (*Fixtures, error) {
+	client := chainclient.NewChainClient(0, s.Prefix, s.ChainID, s.Denom, log)
+
+	f := &Fixtures{
+		Scenario:  name,
+		ChainID:   s.ChainID,
+		Accounts:  make(map[string]AccountFixture, len(s.Accounts)),
+		Contracts: make(map[string]string, len(s.Contracts)),
+		Txs:       make(map[string]TxFixture, len(s.Txs)),
+		Queries:   make(map[string]QueryFixture, len(s.Queries)),
+	}
+	r := &resolver{
+		scenario:  s,
+		addresses: make(map[string]string, len(s.Accounts)+len(s.Contracts)),
+		files:     make(map[string]string, len(s.Files)),
+	}
+
+	for _, spec := range s.Accounts {
+		err := client.AddOfflineAccountWithOptions(spec.Name, spec.Mnemonic, spec.Sequence, spec.Number, chainclient.AccountOptions{
+			KeyType: chainclient.KeyType(spec.KeyType),
+			HDPath:  spec.HDPath,
+		})
+		if err != nil {
+			return nil, err
+		}
+		acc, ok := client.GetAccount(spec.Name)
+		if !ok {
+			return nil, fmt.Errorf("account %s not found", spec.Name)
+		}
+		f.Accounts[spec.Name] = AccountFixture{Address: acc.Address, Number: spec.Number}
+		r.addresses[spec.Name] = acc.Address
+	}
+
+	for _, spec := range s.Multisigs {
+		err := client.AddOfflineMultisigAccount(spec.Name, spec.Threshold, spec.Keys, spec.Sequence, spec.Number)
+		if err != nil {
+			return nil, fmt.Errorf("multisig %s: %w", spec.Name, err)
+		}
+		if len(spec.Signers) > 0 {
+			if err := client.SetMultisigSigners(spec.Name, spec.Signers); err != nil {
+				return nil, err
+			}
+		}
+		acc, _ := client.GetAccount(spec.Name)
+		f.Accounts[spec.Name] = AccountFixture{Address: acc.Address, Number: spec.Number}
+		r.addresses[spec.Name] = acc.Address
+	}
+
+	for _, spec := range s.Contracts {
+		address, err := bech32.ConvertAndEncode(s.Prefix, wasmkeeper.BuildContractAddressClassic(spec.CodeID, spec.InstanceID))
+		if err != nil {
+			return nil, fmt.Errorf("error encoding contract %s address: %w", spec.Name, err)
+		}
+		f.Contracts[spec.Name] = address
+		r.addresses[spec.Name] = address
+	}
+
+	for _, spec := range s.Txs {
+		tx, err := generateTx(client, r, spec)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", spec.Name, err)
+		}
+		f.Txs[spec.Name] = tx
+		log.Info("transaction signed",
+			zap.String("name", spec.Name),
+			zap.String("signer", tx.Signer),
+			zap.Uint64("sequence", tx.Sequence),
+			zap.Strings("msgs", tx.MsgTypes),
+		)
+	}
+
+	for _, spec := range s.Queries {
+		query, err := generateQuery(client, r, spec)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", spec.Name, err)
+		}
+		f.Queries[spec.Name] = query
+	}
+
+	return f, nil
+}
+
+// generateTx signs the transaction with the next or the explicit sequence of the signer
+func generateTx(client *chainclient.ChainClient, r *resolver, spec TxSpec) (TxFixture, error) {
+	acc, _ := client.GetAccount(spec.Signer)
+	sequence := acc.Sequence
+	if spec.Sequence != nil {
+		sequence = *spec.Sequence
+	}
+	if spec.Genesis {
+		// the ante handler verifies the signatures of the genesis transactions with the account number 0
+		acc.Number = 0
+	}
+
+	msgs := make([]sdk.Msg, 0, len(spec.Msgs))
+	msgTypes := make([]string, 0, len(spec.Msgs))
+	for _, raw := range spec.Msgs {
+		resolved, err := r.resolve(raw)
+		if err != nil {
+			return TxFixture{}, err
+		}
+		msg, err := client.DecodeMsgJSON(resolved)
+		if err != nil {
+			return TxFixture{}, err
+		}
+		msgs = append(msgs, msg)
+		msgTypes = append(msgTypes, sdk.MsgTypeURL(msg))
+	}
+
+	fee, err := sdk.ParseCoinsNormalized(spec.Fees)
+	if err != nil {
+		return TxFixture{}, fmt.Errorf("invalid fees: %w", err)
+	}
+
+	feeGranter, err := r.resolve([]byte(spec.FeeGranter))
+	if err != nil {
+		return TxFixture{}, err
+	}
+
+	unsigned, err := client.GenerateTxWithFeeGranter(msgs, spec.Gas, fee, spec.Memo, string(feeGranter))
+	if err != nil {
+		return TxFixture{}, err
+	}
+	signed, err := signTx(client, unsigned, acc, sequence)
+	if err != nil {
+		return TxFixture{}, err
+	}
+	txBytes, err := client.EncodeTxJSON(signed)
+	if err != nil {
+		return TxFixture{}, err
+	}
+
+	if spec.Sequence == nil {
+		if err := client.IncreaseSequence(spec.Signer); err != nil {
+			return TxFixture{}, err
+		}
+	}
+
+	tx := TxFixture{
+		Signer:   spec.Signer,
+		Sequence: sequence,
+		MsgTypes: msgTypes,
+		Hex:      hex.EncodeToString(txBytes),
+	}
+	if spec.Genesis {
+		tx.JSON = signed
+	}
+	return tx, nil
+}
+
+// signTx signs the tx JSON by the account key or by the signer keys of the multisig account
+func signTx(client *chainclient.ChainClient, unsigned []byte, acc chainclient.AccountInfo, sequence uint64) ([]byte, error) {
+	if len(acc.MultisigSigners) == 0 {
+		return client.SignTxJSON(unsigned, acc.Name, acc.Number, sequence)
+	}
+
+	sigs := make([][]byte, 0, len(acc.MultisigSigners))
+	for _, keyName := range acc.MultisigSigners {
+		sig, err := client.SignMultisigTxJSON(unsigned, keyName, acc.Name, acc.Number, sequence)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+	return client.CombineMultisigTxJSON(unsigned, acc.Name, sequence, sigs)
+}
+
+// generateQuery encodes the query request of the registered proto type
+func generateQuery(client *chainclient.ChainClient, r *resolver, spec QuerySpec) (QueryFixture, error) {
+	typ := gogoproto.MessageType(spec.Type)
+	if typ == nil {
+		return QueryFixture{}, fmt.Errorf("unknown request type %s", spec.Type)
+	}
+	req, ok := reflect.New(typ.Elem()).Interface().(gogoproto.Message)
+	if !ok {
+		return QueryFixture{}, fmt.Errorf("%s is not a proto message", spec.Type)
+	}
+
+	resolved, err := r.resolve(spec.Request)
+	if err != nil {
+		return QueryFixture{}, err
+	}
+	if err := client.Codec.GetMarshaler().UnmarshalJSON(resolved, req); err != nil {
+		return QueryFixture{}, fmt.Errorf("error decoding request: %w", err)
+	}
+	bz, err := client.Codec.GetMarshaler().Marshal(req)
+	if err != nil {
+		return QueryFixture{}, fmt.Errorf("error encoding request: %w", err)
+	}
+
+	return QueryFixture{Path: spec.Path, Hex: hex.EncodeToString(bz)}, nil
+}
+
+// WriteJSON - write the fixture bundle as JSON
+func (f *Fixtures) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding fixtures: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// WriteGo - write the fixture bundle as Go constants of the package, in the scenario order
+func (f *Fixtures) WriteGo(path, pkg string, s *Scenario) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by payload_gen from %s. DO NOT EDIT.\n\n", f.Scenario)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "// ChainID - chain the transactions are signed for\nconst ChainID = %q\n\n", f.ChainID)
+
+	b.WriteString("// Addresses of the accounts and the contracts\nconst (\n")
+	for _, spec := range s.Accounts {
+		fmt.Fprintf(&b, "\tAddress%s = %q\n", goName(spec.Name), f.Accounts[spec.Name].Address)
+	}
+	for _, spec := range s.Contracts {
+		fmt.Fprintf(&b, "\tAddress%s = %q\n", goName(spec.Name), f.Contracts[spec.Name])
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// Hex encoded signed transactions\nconst (\n")
+	for _, spec := range s.Txs {
+		tx := f.Txs[spec.Name]
+		fmt.Fprintf(&b, "\t// Tx%s - signed by %s with sequence %d: %s\n",
+			goName(spec.Name), tx.Signer, tx.Sequence, strings.Join(tx.MsgTypes, ", "))
+		fmt.Fprintf(&b, "\tTx%s = %q\n", goName(spec.Name), tx.Hex)
+	}
+	b.WriteString(")\n")
+
+	if len(s.Queries) > 0 {
+		b.WriteString("\n// Hex encoded query requests and their paths\nconst (\n")
+		for _, spec := range s.Queries {
+			query := f.Queries[spec.Name]
+			fmt.Fprintf(&b, "\tQuery%sPath = %q\n", goName(spec.Name), query.Path)
+			fmt.Fprintf(&b, "\tQuery%s = %q\n", goName(spec.Name), query.Hex)
+		}
+		b.WriteString(")\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("error formatting fixtures: %w", err)
+	}
+	return os.WriteFile(path, src, 0o644)
+}
+
+// goName converts the snake or kebab case name to an exported Go name
+func goName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' || r == '-' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,272 @@
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+)
+
+// Placeholder namespaces which are not account or contract names
+const (
+	namespaceChain  = "chain"
+	namespaceFiles  = "files"
+	namespaceModule = "module"
+)
+
+type (
+	// Scenario - the transactions and queries signed offline for the genesis accounts
+	// of the test chain, the e2e suite broadcasts them in the listed order.
+	Scenario struct {
+		ChainID   string            `json:"chain_id"`
+		Prefix    string            `json:"prefix"`
+		Denom     string            `json:"denom"`
+		Accounts  []AccountSpec     `json:"accounts"`
+		Multisigs []MultisigSpec    `json:"multisigs,omitempty"`
+		Contracts []ContractSpec    `json:"contracts,omitempty"`
+		Files     map[string]string `json:"files,omitempty"`
+		Txs       []TxSpec          `json:"txs"`
+		Queries   []QuerySpec       `json:"queries,omitempty"`
+	}
+
+	// AccountSpec - signer account, the number and the starting sequence
+	// must match the genesis of the chain. KeyType and HDPath default to
+	// the secp256k1 key on the cosmos path.
+	AccountSpec struct {
+		Name     string `json:"name"`
+		Mnemonic string `json:"mnemonic"`
+		Number   uint64 `json:"number"`
+		Sequence uint64 `json:"sequence,omitempty"`
+		KeyType  string `json:"key_type,omitempty"`
+		HDPath   string `json:"hd_path,omitempty"`
+	}
+
+	// MultisigSpec - threshold multisig account of the scenario accounts. The transactions
+	// of the multisig are signed by the Signers, all the keys by default.
+	MultisigSpec struct {
+		Name      string   `json:"name"`
+		Threshold int      `json:"threshold"`
+		Keys      []string `json:"keys"`
+		Signers   []string `json:"signers,omitempty"`
+		Number    uint64   `json:"number"`
+		Sequence  uint64   `json:"sequence,omitempty"`
+	}
+
+	// ContractSpec - contract instantiated by the scenario, the address is derived from
+	// the code id and the global instance id so the later messages can refer to it.
+	ContractSpec struct {
+		Name       string `json:"name"`
+		CodeID     uint64 `json:"code_id"`
+		InstanceID uint64 `json:"instance_id"`
+	}
+
+	// TxSpec - transaction with one or more messages given as JSON with the "@type" field.
+	// Without Sequence the next sequence of the signer is used and increased,
+	// an explicit Sequence signs an alternative transaction and leaves the signer sequence as is.
+	// FeeGranter pays the fees from its fee allowance to the signer.
+	// A Genesis transaction, e.g. the gentx of the validator, is signed with the account number 0
+	// as the genesis transactions are verified before the accounts get their numbers,
+	// its signed JSON is written along with the hex for the gen_txs of the genesis.
+	TxSpec struct {
+		Name       string            `json:"name"`
+		Signer     string            `json:"signer"`
+		Sequence   *uint64           `json:"sequence,omitempty"`
+		Gas        uint64            `json:"gas"`
+		Fees       string            `json:"fees"`
+		FeeGranter string            `json:"fee_granter,omitempty"`
+		Memo       string            `json:"memo,omitempty"`
+		Genesis    bool              `json:"genesis,omitempty"`
+		Msgs       []json.RawMessage `json:"msgs"`
+	}
+
+	// QuerySpec - ABCI query, the request is the JSON of the proto message Type
+	QuerySpec struct {
+		Name    string          `json:"name"`
+		Path    string          `json:"path"`
+		Type    string          `json:"type"`
+		Request json.RawMessage `json:"request"`
+	}
+)
+
+// LoadScenario - load the YAML or JSON scenario.
+// Relative file paths are resolved against the scenario directory.
+func LoadScenario(path string) (*Scenario, error) {
+	s := &Scenario{}
+	if err := chainclient.UnmarshalFile(path, s); err != nil {
+		return nil, fmt.Errorf("error loading scenario: %w", err)
+	}
+
+	for name, file := range s.Files {
+		if !filepath.IsAbs(file) {
+			s.Files[name] = filepath.Join(filepath.Dir(path), file)
+		}
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate - check the names are unique and the transactions refer to known signers
+func (s *Scenario) Validate() error {
+	if s.ChainID == "" || s.Prefix == "" || s.Denom == "" {
+		return fmt.Errorf("chain_id, prefix and denom are required")
+	}
+
+	namespaces := map[string]bool{namespaceChain: true, namespaceFiles: true, namespaceModule: true}
+	for _, acc := range s.Accounts {
+		if acc.Name == "" || acc.Mnemonic == "" {
+			return fmt.Errorf("account name and mnemonic are required")
+		}
+		if namespaces[acc.Name] {
+			return fmt.Errorf("duplicate or reserved name %q", acc.Name)
+		}
+		namespaces[acc.Name] = true
+	}
+	for _, msig := range s.Multisigs {
+		if msig.Name == "" || len(msig.Keys) == 0 {
+			return fmt.Errorf("multisig name and keys are required")
+		}
+		if namespaces[msig.Name] {
+			return fmt.Errorf("duplicate or reserved name %q", msig.Name)
+		}
+		namespaces[msig.Name] = true
+		for _, key := range append(append([]string{}, msig.Keys...), msig.Signers...) {
+			if s.account(key) == nil {
+				return fmt.Errorf("multisig %s: unknown key %q", msig.Name, key)
+			}
+		}
+	}
+	for _, contract := range s.Contracts {
+		if contract.Name == "" || contract.CodeID == 0 || contract.InstanceID == 0 {
+			return fmt.Errorf("contract name, code_id and instance_id are required")
+		}
+		if namespaces[contract.Name] {
+			return fmt.Errorf("duplicate or reserved name %q", contract.Name)
+		}
+		namespaces[contract.Name] = true
+	}
+
+	if len(s.Txs) == 0 {
+		return fmt.Errorf("scenario has no transactions")
+	}
+	txs := make(map[string]bool, len(s.Txs))
+	for _, tx := range s.Txs {
+		if tx.Name == "" || txs[tx.Name] {
+			return fmt.Errorf("empty or duplicate transaction name %q", tx.Name)
+		}
+		txs[tx.Name] = true
+		if s.account(tx.Signer) == nil && s.multisig(tx.Signer) == nil {
+			return fmt.Errorf("transaction %s: unknown signer %q", tx.Name, tx.Signer)
+		}
+		if len(tx.Msgs) == 0 {
+			return fmt.Errorf("transaction %s has no messages", tx.Name)
+		}
+	}
+
+	queries := make(map[string]bool, len(s.Queries))
+	for _, q := range s.Queries {
+		if q.Name == "" || queries[q.Name] {
+			return fmt.Errorf("empty or duplicate query name %q", q.Name)
+		}
+		queries[q.Name] = true
+		if q.Path == "" || q.Type == "" {
+			return fmt.Errorf("query %s: path and type are required", q.Name)
+		}
+	}
+
+	return nil
+}
+
+// account returns the account spec by name
+func (s *Scenario) account(name string) *AccountSpec {
+	for i := range s.Accounts {
+		if s.Accounts[i].Name == name {
+			return &s.Accounts[i]
+		}
+	}
+	return nil
+}
+
+// multisig returns the multisig spec by name
+func (s *Scenario) multisig(name string) *MultisigSpec {
+	for i := range s.Multisigs {
+		if s.Multisigs[i].Name == name {
+			return &s.Multisigs[i]
+		}
+	}
+	return nil
+}
+
+// resolver resolves the placeholders of the scenario messages
+type resolver struct {
+	scenario  *Scenario
+	addresses map[string]string
+	files     map[string]string
+}
+
+// resolve replaces the placeholders in the JSON message, files are base64 encoded
+// as the proto JSON of the bytes fields
+func (r *resolver) resolve(msg []byte) ([]byte, error) {
+	resolved, err := chainclient.ReplacePlaceholders(string(msg), r.lookup)
+	return []byte(resolved), err
+}
+
+// lookup returns the value of the placeholder namespace.key
+func (r *resolver) lookup(p chainclient.Placeholder) (string, error) {
+	if p.Offset != "" || p.Type != "" {
+		return "", fmt.Errorf("offset and type are not supported by placeholder %s", p)
+	}
+
+	namespace, key := p.Namespace, p.Key
+	switch namespace {
+	case namespaceChain:
+		switch key {
+		case "id":
+			return r.scenario.ChainID, nil
+		case "denom":
+			return r.scenario.Denom, nil
+		}
+	case namespaceFiles:
+		if encoded, ok := r.files[key]; ok {
+			return encoded, nil
+		}
+		path, ok := r.scenario.Files[key]
+		if !ok {
+			return "", fmt.Errorf("unknown file %q", key)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading file %s: %w", key, err)
+		}
+		r.files[key] = base64.StdEncoding.EncodeToString(data)
+		return r.files[key], nil
+	case namespaceModule:
+		// module account address, e.g. the authority of the messages executed by gov
+		return bech32.ConvertAndEncode(r.scenario.Prefix, authtypes.NewModuleAddress(key))
+	default:
+		address, ok := r.addresses[namespace]
+		if !ok {
+			break
+		}
+		switch key {
+		case "address":
+			return address, nil
+		case "valoper":
+			// operator address of the validator created by the account
+			_, bz, err := bech32.DecodeAndConvert(address)
+			if err != nil {
+				return "", err
+			}
+			return bech32.ConvertAndEncode(r.scenario.Prefix+"valoper", bz)
+		}
+	}
+	return "", fmt.Errorf("unknown placeholder %s", p)
+}
@@ -1,67 +1,80 @@
 package main
 
 import (
-	_ "embed"
+	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"go.uber.org/zap"
 
 	"payload_gen/internal"
-	resp "payload_gen/internal/responses"
-)
-
-var (
-	//go:embed testdata/nameservice.wasm
-	nameserviceWasm []byte
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "tx" {
+		if err := runTx(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var (
+		scenarioPath = flag.String("scenario", "scenarios/wasm.yaml", "YAML or JSON scenario with the accounts, transactions and queries")
+		outFormat    = flag.String("format", "json", "output format: json bundle or go source")
+		out          = flag.String("out", "", "output file, defaults to the scenario name with the format extension")
+		pkg          = flag.String("package", "fixtures", "package name of the go source")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-scenario file] [-format json|go] [-out file]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s tx <generate|sign|broadcast> [flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
 	// Create the logger
 	logger, err := zap.NewProduction()
 	if err != nil {
 		panic(fmt.Sprintf("can't initialize zap logger: %v", err))
 	}
 	defer logger.Sync() // flushes buffer, if any
-	log := logger.Sugar()
 
-	client := internal.NewChainClient(300000, log)
-
-	resp.PrintDecodedBalances(log)
+	if *outFormat != "json" && *outFormat != "go" {
+		flag.Usage()
+		os.Exit(2)
+	}
 
-	client.AddAccount("user1", internal.User1Mnemonic, 0, 1)
-	acc1, exist := client.GetAccount("user1")
-	if !exist {
-		log.Fatalf("account not found")
+	scenario, err := internal.LoadScenario(*scenarioPath)
+	if err != nil {
+		logger.Fatal("error loading scenario", zap.Error(err))
 		return
 	}
-	log.Infof("user1 address: %s", acc1.Address)
 
-	client.AddAccount("user2", internal.User2Mnemonic, 0, 2)
-	acc2, exist := client.GetAccount("user2")
-	if !exist {
-		log.Fatalf("account not found")
+	name := filepath.Base(*scenarioPath)
+	fixtures, err := internal.Generate(scenario, name, logger)
+	if err != nil {
+		logger.Fatal("error generating fixtures", zap.Error(err))
 		return
 	}
-	log.Infof("user2 address: %s", acc2.Address)
-
-	internal.GetQueryAllBalancesRequestHex(log, acc1.Address)
-	internal.GetQueryAllBalancesRequestHex(log, acc2.Address)
 
-	internal.BankSendTxHex(client, log, "user1", "user2", 5000000)
-	err = client.IncreaseSequence("user1")
+	if *out == "" {
+		*out = name[:len(name)-len(filepath.Ext(name))] + "." + *outFormat
+	}
+	if *outFormat == "go" {
+		err = fixtures.WriteGo(*out, *pkg, scenario)
+	} else {
+		err = fixtures.WriteJSON(*out)
+	}
 	if err != nil {
-		log.Fatalf("error increasing sequence: %v", err)
+		logger.Fatal("error writing fixtures", zap.Error(err))
+		return
 	}
 
-	// internal.DeployContractHex(client, log, "user1", nameserviceWasm, "testdata/nameservice.wasm.hex")
-	err = client.IncreaseSequence("user1")
-
-	internal.MsgInstantiateContractHex(client, log, "user1")
-	err = client.IncreaseSequence("user1")
-
-	const smartContractAddress = "wasm14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s0phg4d"
-	internal.MsgExecuteContractHex(client, log, "user1", smartContractAddress)
-	err = client.IncreaseSequence("user1")
-
-	internal.GetQuerySmartContractStateRequestHex(log, smartContractAddress)
+	logger.Info("fixtures written",
+		zap.String("scenario", *scenarioPath),
+		zap.String("out", *out),
+		zap.Int("txs", len(fixtures.Txs)),
+		zap.Int("queries", len(fixtures.Queries)),
+	)
 }
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cosmossdk.io/math"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+
+	"github.com/consideritdone/landslide-runner/chainclient"
+)
+
+const txUsage = `Usage: %s tx <command> [flags]
+
+Commands:
+  generate    write the unsigned tx JSON with the messages given by -msg
+  sign        sign the tx JSON offline with a keyring key, the account number and the sequence
+  multisign   combine the signatures of the multisig keys made by sign -multisig
+  broadcast   broadcast the signed tx JSON to a Landslide RPC
+
+`
+
+// listFlag collects repeated flag values
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// runTx runs the tx subcommand
+func runTx(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintf(os.Stderr, txUsage, os.Args[0])
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "generate":
+		return runTxGenerate(args[1:])
+	case "sign":
+		return runTxSign(args[1:])
+	case "multisign":
+		return runTxMultisign(args[1:])
+	case "broadcast":
+		return runTxBroadcast(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, txUsage, os.Args[0])
+		return fmt.Errorf("unknown tx command %q", args[0])
+	}
+}
+
+// runTxGenerate writes the unsigned tx JSON
+func runTxGenerate(args []string) error {
+	fs := flag.NewFlagSet("tx generate", flag.ExitOnError)
+	var msgs listFlag
+	fs.Var(&msgs, "msg", `message JSON with the "@type" field or @file to read it from the file, can be repeated`)
+	gas := fs.Uint64("gas", 300000, "gas limit")
+	gasPrices := fs.String("gas-prices", "0.5"+chainclient.DefaultDenom, "gas price the fee is calculated from")
+	fees := fs.String("fees", "", "fee, overrides -gas-prices")
+	memo := fs.String("memo", "", "memo")
+	feeGranter := fs.String("fee-granter", "", "address of the granter whose fee allowance pays the fee")
+	prefix := fs.String("prefix", chainclient.DefaultPrefix, "account address prefix")
+	out := fs.String("out", "", "output file, defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(msgs) == 0 {
+		return errors.New("at least one -msg is required")
+	}
+
+	client := chainclient.NewChainClient(*gas, *prefix, chainclient.DefaultChainID, chainclient.DefaultDenom, zap.NewNop())
+
+	decoded := make([]sdk.Msg, 0, len(msgs))
+	for _, m := range msgs {
+		data := []byte(m)
+		if strings.HasPrefix(m, "@") {
+			var err error
+			if data, err = os.ReadFile(m[1:]); err != nil {
+				return err
+			}
+		}
+		msg, err := client.DecodeMsgJSON(data)
+		if err != nil {
+			return err
+		}
+		decoded = append(decoded, msg)
+	}
+
+	fee, err := txFee(*gas, *gasPrices, *fees)
+	if err != nil {
+		return err
+	}
+
+	txJSON, err := client.GenerateTxWithFeeGranter(decoded, *gas, fee, *memo, *feeGranter)
+	if err != nil {
+		return err
+	}
+	return writeOutput(*out, txJSON)
+}
+
+// runTxSign signs the tx JSON offline
+func runTxSign(args []string) error {
+	fs := flag.NewFlagSet("tx sign", flag.ExitOnError)
+	from := fs.String("from", "", "name of the keyring key")
+	accountNumber := fs.Uint64("account-number", 0, "account number of the signer, required")
+	sequence := fs.Uint64("sequence", 0, "sequence of the signer, required")
+	chainID := fs.String("chain-id", chainclient.DefaultChainID, "chain ID")
+	prefix := fs.String("prefix", chainclient.DefaultPrefix, "account address prefix")
+	keyringBackend := fs.String("keyring-backend", keyring.BackendTest, "keyring backend: os, file or test")
+	keyringDir := fs.String("keyring-dir", chainclient.DefaultKeyringDir(), "directory of the file and test keyrings")
+	multisigName := fs.String("multisig", "", "name of the multisig key, writes the signature of -from for tx multisign")
+	out := fs.String("out", "", "output file, defaults to stdout")
+	hexOut := fs.Bool("hex", false, "write the hex encoded tx bytes instead of the JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s tx sign [flags] <unsigned tx file>\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *from == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	for _, name := range []string{"account-number", "sequence"} {
+		if !isFlagSet(fs, name) {
+			return fmt.Errorf("-%s is required to sign offline", name)
+		}
+	}
+
+	txJSON, err := readInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := chainclient.SetPrefixes(*prefix); err != nil {
+		return err
+	}
+	kr, err := chainclient.NewKeyringWithBackend(*keyringBackend, *keyringDir, os.Stdin)
+	if err != nil {
+		return err
+	}
+	client := chainclient.NewChainClientWithKeyring(0, *prefix, *chainID, chainclient.DefaultDenom, kr, zap.NewNop())
+
+	if *multisigName != "" {
+		if *hexOut {
+			return errors.New("-hex can't be used with -multisig, the signatures are combined by tx multisign")
+		}
+		sig, err := client.SignMultisigTxJSON(txJSON, *from, *multisigName, *accountNumber, *sequence)
+		if err != nil {
+			return err
+		}
+		return writeOutput(*out, sig)
+	}
+
+	signed, err := client.SignTxJSON(txJSON, *from, *accountNumber, *sequence)
+	if err != nil {
+		return err
+	}
+	return writeSigned(client, *out, signed, *hexOut)
+}
+
+// runTxMultisign combines the signatures of the multisig keys into the signed tx JSON
+func runTxMultisign(args []string) error {
+	fs := flag.NewFlagSet("tx multisign", flag.ExitOnError)
+	multisigName := fs.String("multisig", "", "name of the multisig key")
+	sequence := fs.Uint64("sequence", 0, "sequence of the multisig account, required")
+	prefix := fs.String("prefix", chainclient.DefaultPrefix, "account address prefix")
+	keyringBackend := fs.String("keyring-backend", keyring.BackendTest, "keyring backend: os, file or test")
+	keyringDir := fs.String("keyring-dir", chainclient.DefaultKeyringDir(), "directory of the file and test keyrings")
+	out := fs.String("out", "", "output file, defaults to stdout")
+	hexOut := fs.Bool("hex", false, "write the hex encoded tx bytes instead of the JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s tx multisign [flags] <unsigned tx file> <signature file>...\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 || *multisigName == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if !isFlagSet(fs, "sequence") {
+		return errors.New("-sequence is required to combine the signatures")
+	}
+
+	txJSON, err := readInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	sigs := make([][]byte, 0, fs.NArg()-1)
+	for _, path := range fs.Args()[1:] {
+		sig, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sigs = append(sigs, sig)
+	}
+
+	if err := chainclient.SetPrefixes(*prefix); err != nil {
+		return err
+	}
+	kr, err := chainclient.NewKeyringWithBackend(*keyringBackend, *keyringDir, os.Stdin)
+	if err != nil {
+		return err
+	}
+	client := chainclient.NewChainClientWithKeyring(0, *prefix, chainclient.DefaultChainID, chainclient.DefaultDenom, kr, zap.NewNop())
+
+	signed, err := client.CombineMultisigTxJSON(txJSON, *multisigName, *sequence, sigs)
+	if err != nil {
+		return err
+	}
+	return writeSigned(client, *out, signed, *hexOut)
+}
+
+// writeSigned writes the signed tx JSON or its hex encoded bytes
+func writeSigned(client *chainclient.ChainClient, path string, signed []byte, hexOut bool) error {
+	if hexOut {
+		txBytes, err := client.EncodeTxJSON(signed)
+		if err != nil {
+			return err
+		}
+		return writeOutput(path, []byte(hex.EncodeToString(txBytes)))
+	}
+	return writeOutput(path, signed)
+}
+
+// runTxBroadcast broadcasts the signed tx JSON
+func runTxBroadcast(args []string) error {
+	fs := flag.NewFlagSet("tx broadcast", flag.ExitOnError)
+	rpcAddr := fs.String("rpc", "", "RPC address, defaults to the local node address of -blockchain-id")
+	blockchainID := fs.String("blockchain-id", "", "Landslide blockchain ID")
+	wait := fs.Bool("wait", false, "wait until the transaction is committed")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s tx broadcast [flags] <signed tx file>\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	addr := *rpcAddr
+	if addr == "" {
+		if *blockchainID == "" {
+			return errors.New("either -rpc or -blockchain-id is required")
+		}
+		addr = "http://127.0.0.1:9750/ext/bc/" + *blockchainID + "/rpc"
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	txJSON, err := readInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	client := chainclient.NewChainClient(0, chainclient.DefaultPrefix, chainclient.DefaultChainID, chainclient.DefaultDenom, logger)
+	txBytes, err := client.EncodeTxJSON(txJSON)
+	if err != nil {
+		return err
+	}
+
+	c, err := rpchttp.New(addr, "/websocket")
+	if err != nil {
+		return err
+	}
+
+	res, err := c.BroadcastTxSync(context.Background(), txBytes)
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return fmt.Errorf("transaction rejected, code %d: %s", res.Code, res.Log)
+	}
+	logger.Info("Transaction broadcast", zap.String("txHash", res.Hash.String()))
+
+	if *wait {
+		resTx, err := chainclient.NewChainService(client, c, logger).WaitTx(res.Hash)
+		if err != nil {
+			return err
+		}
+		logger.Info("Transaction committed",
+			zap.Int64("height", resTx.Height),
+			zap.Int64("gasUsed", resTx.TxResult.GasUsed),
+		)
+	}
+	return nil
+}
+
+// txFee returns the fee or the fee calculated from the gas limit and the gas price
+func txFee(gas uint64, gasPrices, fees string) (sdk.Coins, error) {
+	if fees != "" {
+		return sdk.ParseCoinsNormalized(fees)
+	}
+
+	price, err := sdk.ParseDecCoin(gasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas prices: %w", err)
+	}
+	amount := price.Amount.Mul(math.LegacyNewDec(int64(gas))).Ceil().TruncateInt()
+	return sdk.NewCoins(sdk.NewCoin(price.Denom, amount)), nil
+}
+
+// readInput reads the file or stdin if the path is "-"
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// writeOutput writes the data with a trailing new line to the file or stdout
+func writeOutput(path string, data []byte) error {
+	data = append(data, '\n')
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// isFlagSet reports whether the flag was passed on the command line
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
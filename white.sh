@@ -5,5 +5,5 @@ if [ "$#" -ne 1 ]; then
     exit 1
 fi
 
-cd ./tools/white_whale; go run main.go $1
+cd ./tools/deploy; go run . -manifest ../white_whale/deploy.yaml -env ../white_whale/.env $1
 